@@ -63,6 +63,7 @@ from .utils import (
     RecursiveCharacterTextSplitter,
     Relation,
     TextSplitter,
+    extract_snippet,
     format_entity_types,
     format_relations,
     generate_id_from_label,
@@ -153,4 +154,5 @@ __all__ = [
     "run_pipeline",
     "generate_run_id",
     "generate_id_from_label",
+    "extract_snippet",
 ]
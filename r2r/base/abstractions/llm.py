@@ -1,6 +1,6 @@
 """Abstractions for the LLM model."""
 
-from typing import TYPE_CHECKING, Optional
+from typing import TYPE_CHECKING, ClassVar, Optional
 
 from openai.types.chat import ChatCompletion, ChatCompletionChunk
 from pydantic import BaseModel
@@ -18,11 +18,14 @@ class RAGCompletion:
 
     def __init__(
         self,
-        completion: LLMChatCompletion,
+        completion: Optional[LLMChatCompletion],
         search_results: "AggregateSearchResult",
+        messages: Optional[list[dict]] = None,
     ):
         self.completion = completion
         self.search_results = search_results
+        # The prompt sent to the LLM, only set for dry runs
+        self.messages = messages
 
 
 class GenerationConfig(BaseModel):
@@ -41,3 +44,21 @@ class GenerationConfig(BaseModel):
     generate_with_chat: bool = False
     add_generation_kwargs: Optional[dict] = {}
     api_base: Optional[str] = None
+    stop: Optional[list[str]] = None
+    # Token ID to bias, in the format the OpenAI API expects
+    logit_bias: Optional[dict[str, float]] = None
+
+    presets: ClassVar[dict[str, "GenerationConfig"]] = {}
+
+    @classmethod
+    def register_preset(cls, name: str, config: "GenerationConfig") -> None:
+        cls.presets[name] = config
+
+    @classmethod
+    def from_preset(cls, name: str, **overrides) -> "GenerationConfig":
+        if name not in cls.presets:
+            raise ValueError(
+                f"Unknown generation preset '{name}', expected one of {sorted(cls.presets)}."
+            )
+        return cls.presets[name].model_copy(update=overrides)
+
@@ -22,6 +22,9 @@ class VectorSearchResult(BaseModel):
     id: uuid.UUID
     score: float
     metadata: dict[str, Any]
+    embedding: Optional[list[float]] = None
+    # Set by reranking providers, `score` keeps the vector similarity
+    rerank_score: Optional[float] = None
 
     def __str__(self) -> str:
         return f"VectorSearchResult(id={self.id}, score={self.score}, metadata={self.metadata})"
@@ -30,11 +33,15 @@ class VectorSearchResult(BaseModel):
         return f"VectorSearchResult(id={self.id}, score={self.score}, metadata={self.metadata})"
 
     def dict(self) -> dict:
-        return {
+        result = {
             "id": self.id,
             "score": self.score,
+            "rerank_score": self.rerank_score,
             "metadata": self.metadata,
         }
+        if self.embedding is not None:
+            result["embedding"] = self.embedding
+        return result
 
 
 class KGSearchRequest(BaseModel):
@@ -52,6 +59,8 @@ class AggregateSearchResult(BaseModel):
 
     vector_search_results: Optional[List[VectorSearchResult]]
     kg_search_results: Optional[KGSearchResult] = None
+    # The vector the query was searched with, see `include_embeddings`
+    query_embedding: Optional[list[float]] = None
 
     def __str__(self) -> str:
         return f"AggregateSearchResult(vector_search_results={self.vector_search_results}, kg_search_results={self.kg_search_results})"
@@ -60,7 +69,7 @@ class AggregateSearchResult(BaseModel):
         return f"AggregateSearchResult(vector_search_results={self.vector_search_results}, kg_search_results={self.kg_search_results})"
 
     def dict(self) -> dict:
-        return {
+        result = {
             "vector_search_results": (
                 [result.dict() for result in self.vector_search_results]
                 if self.vector_search_results
@@ -68,13 +77,23 @@ class AggregateSearchResult(BaseModel):
             ),
             "kg_search_results": self.kg_search_results or [],
         }
+        if self.query_embedding is not None:
+            result["query_embedding"] = self.query_embedding
+        return result
 
 
 class VectorSearchSettings(BaseModel):
     use_vector_search: bool = True
     search_filters: dict[str, Any] = Field(default_factory=dict)
     search_limit: int = 10
+    # Results kept after reranking the `search_limit` candidates
+    rerank_limit: Optional[int] = None
     do_hybrid_search: bool = False
+    # Only affect what `search` returns, RAG always sees the full chunks
+    include_metadata: bool = True
+    include_text: bool = True
+    # Debugging aid, returns the query and chunk vectors with the results
+    include_embeddings: bool = False
 
 
 class KGSearchSettings(BaseModel):
@@ -70,7 +70,8 @@ class AsyncPipeline:
                 f"Invalid pipeline type: {self.pipeline_type}, must be one of {PipelineTypes.__members__.keys()}"
             )
 
-        self.state = state or AsyncState()
+        state = state or AsyncState()
+        self.state = state
         current_input = input
         async with manage_run(run_manager, self.pipeline_type):
             if log_run_info:
@@ -88,6 +89,7 @@ class AsyncPipeline:
                         pipe_num,
                         current_input,
                         run_manager,
+                        state,
                         *args,
                         **kwargs,
                     )
@@ -118,6 +120,7 @@ class AsyncPipeline:
         pipe_num: int,
         input: Any,
         run_manager: RunManager,
+        state: AsyncState,
         *args: Any,
         **kwargs: Any,
     ):
@@ -157,7 +160,7 @@ class AsyncPipeline:
                 input_dict["message"] = replay_items_as_async_gen(temp_results)
 
             for upstream_input in upstream_inputs:
-                outputs = await self.state.get(upstream_pipe_name, "output")
+                outputs = await state.get(upstream_pipe_name, "output")
                 prev_output_field = upstream_input.get(
                     "prev_output_field", None
                 )
@@ -172,7 +175,7 @@ class AsyncPipeline:
         # Handle the pipe generator
         async for ele in await pipe.run(
             pipe.Input(**input_dict),
-            self.state,
+            state,
             run_manager,
             *args,
             **kwargs,
@@ -1,6 +1,6 @@
 import logging
 from abc import ABC, abstractmethod
-from typing import Optional, Union
+from typing import Any, Optional, Union
 
 from ..abstractions.document import DocumentInfo
 from ..abstractions.search import VectorSearchResult
@@ -124,11 +124,20 @@ class VectorDBProvider(Provider, ABC):
         self,
         filter_document_ids: Optional[list[str]] = None,
         filter_user_ids: Optional[list[str]] = None,
+        filter_metadata: Optional[dict[str, Any]] = None,
+        offset: int = 0,
+        limit: Optional[int] = None,
+        after: Optional[tuple[str, str]] = None,
     ) -> list[DocumentInfo]:
         pass
 
     @abstractmethod
-    def get_document_chunks(self, document_id: str) -> list[dict]:
+    def get_document_chunks(
+        self,
+        document_id: str,
+        offset: int = 0,
+        limit: Optional[int] = None,
+    ) -> list[dict]:
         pass
 
     @abstractmethod
@@ -1,6 +1,7 @@
 from .base_utils import (
     EntityType,
     Relation,
+    extract_snippet,
     format_entity_types,
     format_relations,
     generate_id_from_label,
@@ -23,4 +24,5 @@ __all__ = [
     "Relation",
     "format_entity_types",
     "format_relations",
+    "extract_snippet",
 ]
@@ -1,6 +1,15 @@
 import asyncio
+import re
 import uuid
-from typing import TYPE_CHECKING, Any, AsyncGenerator, Iterable, List, Optional
+from typing import (
+    TYPE_CHECKING,
+    Any,
+    AsyncGenerator,
+    Iterable,
+    List,
+    Optional,
+    Tuple,
+)
 
 if TYPE_CHECKING:
     from ..pipeline.base_pipeline import AsyncPipeline
@@ -39,6 +48,51 @@ def increment_version(version: str) -> str:
     return f"{prefix}{suffix + 1}"
 
 
+def extract_snippet(
+    text: str,
+    query: str,
+    window: int = 200,
+    highlight_tags: Optional[Tuple[str, str]] = None,
+) -> str:
+    """Return the `window`-sized part of `text` densest in query terms."""
+    terms = {
+        term for term in re.findall(r"\w+", query.lower()) if len(term) > 2
+    }
+    if not terms:
+        return text[:window]
+    pattern = re.compile(
+        r"\b(" + "|".join(re.escape(term) for term in terms) + r")\b",
+        re.IGNORECASE,
+    )
+    hits = [match.start() for match in pattern.finditer(text)]
+    if not hits:
+        return text[:window]
+
+    best_start, best_count = hits[0], 0
+    for start in hits:
+        count = sum(1 for hit in hits if start <= hit < start + window)
+        if count > best_count:
+            best_start, best_count = start, count
+
+    start = max(0, min(best_start - window // 4, len(text) - window))
+    end = start + window
+    # Widen the window rather than cut a matched term in half
+    for match in pattern.finditer(text):
+        if match.start() < start < match.end():
+            start = match.start()
+        if match.start() < end < match.end():
+            end = match.end()
+    snippet = text[start:end]
+    if highlight_tags:
+        open_tag, close_tag = highlight_tags
+        snippet = pattern.sub(
+            lambda match: f"{open_tag}{match.group(0)}{close_tag}", snippet
+        )
+    prefix = "..." if start > 0 else ""
+    suffix = "..." if end < len(text) else ""
+    return f"{prefix}{snippet}{suffix}"
+
+
 class EntityType:
     def __init__(self, name: str, subcategories: Optional[List[str]] = None):
         self.name = name
@@ -1,5 +1,6 @@
 import json
 import os
+import sys
 import time
 import uuid
 
@@ -13,6 +14,7 @@ from r2r import (
     R2RClient,
     R2RConfig,
     generate_id_from_label,
+    unwrap_results,
 )
 from r2r.base import (
     AnalysisTypes,
@@ -35,6 +37,25 @@ class JsonParamType(click.ParamType):
 JSON = JsonParamType()
 
 
+class TeeStream:
+    """Writes to the original stream and mirrors the output to a file."""
+
+    def __init__(self, stream, file):
+        self.stream = stream
+        self.file = file
+
+    def write(self, data):
+        self.file.write(data)
+        return self.stream.write(data)
+
+    def flush(self):
+        self.file.flush()
+        self.stream.flush()
+
+    def __getattr__(self, name):
+        return getattr(self.stream, name)
+
+
 @click.group()
 @click.option(
     "--config-path", default=None, help="Path to the configuration file"
@@ -50,9 +71,46 @@ JSON = JsonParamType()
     default="http://localhost:8000",
     help="Base URL for client-server mode",
 )
+@click.option(
+    "--output-file",
+    type=click.Path(dir_okay=False, writable=True),
+    default=None,
+    help="Also write command output to this file",
+)
+@click.option(
+    "--timeout",
+    type=float,
+    default=None,
+    help="Request timeout in seconds for client-server mode",
+)
+@click.option(
+    "--retries",
+    type=int,
+    default=0,
+    help="Times to retry a rate-limited (429) request in client-server mode",
+)
 @click.pass_context
-def cli(ctx, config_path, config_name, client_server_mode, base_url):
+def cli(
+    ctx,
+    config_path,
+    config_name,
+    client_server_mode,
+    base_url,
+    output_file,
+    timeout,
+    retries,
+):
     """R2R CLI for all core operations."""
+    if output_file:
+        output = open(output_file, "w")
+        sys.stdout = TeeStream(sys.stdout, output)
+
+        def close_output():
+            sys.stdout = sys.stdout.stream
+            output.close()
+
+        ctx.call_on_close(close_output)
+
     if config_path and config_name != "default":
         raise click.UsageError(
             "Cannot specify both config_path and config_name"
@@ -64,7 +122,9 @@ def cli(ctx, config_path, config_name, client_server_mode, base_url):
         config = R2RConfig.from_json(R2RBuilder.CONFIG_OPTIONS[config_name])
 
     if client_server_mode and ctx.invoked_subcommand != "serve":
-        ctx.obj = R2RClient(base_url)
+        ctx.obj = R2RClient(
+            base_url, timeout=timeout, max_rate_limit_retries=retries
+        )
     else:
         ctx.obj = R2R(config)
 
@@ -257,7 +317,13 @@ def update_documents(obj, file_tuples):
 @click.option(
     "--use-kg-search", is_flag=True, help="Use knowledge graph search"
 )
-@click.option("--kg-agent-model", default="gpt-4o", help="Model for KG agent")
+@click.option(
+    "--kg-agent-model",
+    "--kg-search-model",
+    "kg_agent_model",
+    default="gpt-4o",
+    help="Model the KG agent uses to write graph queries",
+)
 @click.pass_obj
 def search(
     obj,
@@ -298,8 +364,7 @@ def search(
             ),
         )
 
-    if isinstance(results, dict) and "results" in results:
-        results = results["results"]
+    results = unwrap_results(results)
 
     if "vector_search_results" in results:
         click.echo("Vector search results:")
@@ -327,7 +392,13 @@ def search(
 @click.option(
     "--use-kg-search", is_flag=True, help="Use knowledge graph search"
 )
-@click.option("--kg-agent-model", default="gpt-4o", help="Model for KG agent")
+@click.option(
+    "--kg-agent-model",
+    "--kg-search-model",
+    "kg_agent_model",
+    default="gpt-4o",
+    help="Model the KG agent uses to write graph queries",
+)
 @click.option("--rag-model", default="gpt-4o", help="Model to use for RAG")
 @click.option("--stream", is_flag=True, help="Stream the RAG response")
 @click.pass_obj
@@ -361,7 +432,7 @@ def rag(
             rag_generation_config=rag_generation_config,
         )
         if not stream:
-            response = response["results"]
+            response = unwrap_results(response)
             t1 = time.time()
             click.echo(f"Time taken to get RAG response: {t1-t0:.2f} seconds")
             click.echo(f"Search Results:\n{response['search_results']}")
@@ -455,12 +526,19 @@ def evaluate(obj, query, context, completion, eval_model):
 @click.option("--keys", multiple=True, help="Keys for deletion")
 @click.option("--values", multiple=True, help="Values for deletion")
 @click.option("--version", help="Version for deletion")
+@click.option("--yes", is_flag=True, help="Delete without asking to confirm")
 @click.pass_obj
-def delete(obj, keys, values, version):
+def delete(obj, keys, values, version, yes):
     """Delete documents from R2R."""
     if version:
         keys = list(keys) + ["version"]
         values = list(values) + [version]
+    if not yes:
+        # Deletes are permanent, there is no way to restore documents
+        click.confirm(
+            f"Permanently delete all documents matching {dict(zip(keys, values))}?",
+            abort=True,
+        )
     t0 = time.time()
     response = obj.delete(keys, values)
     t1 = time.time()
@@ -470,9 +548,22 @@ def delete(obj, keys, values, version):
 
 @cli.command()
 @click.option("--log-type-filter", help="Filter for specific log types")
+@click.option("--follow", is_flag=True, help="Keep printing new log entries")
+@click.option(
+    "--interval", default=2.0, help="Seconds between polls with --follow"
+)
 @click.pass_obj
-def logs(obj, log_type_filter):
+def logs(obj, log_type_filter, follow, interval):
     """Retrieve logs from R2R."""
+    if follow:
+        if not isinstance(obj, R2RClient):
+            raise click.UsageError("--follow requires client-server mode")
+        for run in obj.follow_logs(log_type_filter, interval=interval):
+            for entry in run["entries"]:
+                click.echo(
+                    f"[{run['run_type']} {run['run_id']}] {entry['key']}: {entry['value']}"
+                )
+        return
     t0 = time.time()
     response = obj.logs(log_type_filter)
     t1 = time.time()
@@ -535,8 +626,7 @@ def users_overview(obj, user_ids=None):
         )
     t1 = time.time()
     click.echo(f"Time taken to get user stats: {t1-t0:.2f} seconds")
-    if isinstance(response, dict) and "results" in response:
-        response = response["results"]
+    response = unwrap_results(response)
     for user in response:
         click.echo(user)
 
@@ -544,24 +634,45 @@ def users_overview(obj, user_ids=None):
 @cli.command()
 @click.option("--document-ids", multiple=True, help="Document IDs to overview")
 @click.option("--user-ids", multiple=True, help="User IDs to filter documents")
+@click.option(
+    "--metadata-filters", type=JSON, help="Document metadata filters as JSON"
+)
+@click.option("--offset", default=0, help="Number of documents to skip")
+@click.option("--limit", default=None, type=int, help="Maximum documents")
+@click.option("--fields", multiple=True, help="Only return these fields")
 @click.pass_obj
-def documents_overview(obj, document_ids=None, user_ids=None):
+def documents_overview(
+    obj,
+    document_ids=None,
+    user_ids=None,
+    metadata_filters=None,
+    offset=0,
+    limit=None,
+    fields=None,
+):
     """Get an overview of documents."""
     t0 = time.time()
     if isinstance(obj, R2RClient):
         results = obj.documents_overview(
             list(document_ids) if document_ids else None,
             list(user_ids) if user_ids else None,
+            metadata_filters=metadata_filters,
+            offset=offset,
+            limit=limit,
+            fields=list(fields) if fields else None,
         )
     else:
         results = obj.documents_overview(
             list(document_ids) if document_ids else None,
             list(user_ids) if user_ids else None,
+            metadata_filters=metadata_filters,
+            offset=offset,
+            limit=limit,
+            fields=list(fields) if fields else None,
         )
     t1 = time.time()
     click.echo(f"Time taken to get document info: {t1-t0:.2f} seconds")
-    if isinstance(results, dict) and "results" in results:
-        results = results["results"]
+    results = unwrap_results(results)
     for document in results:
         click.echo(document)
 
@@ -579,12 +690,77 @@ def document_chunks(obj, document_id):
         results = obj.document_chunks(doc_uuid)
     t1 = time.time()
     click.echo(f"Time taken to get document chunks: {t1-t0:.2f} seconds")
-    if isinstance(results, dict) and "results" in results:
-        results = results["results"]
+    results = unwrap_results(results)
     for chunk in results:
         click.echo(chunk)
 
 
+@cli.group()
+def prompts():
+    """Manage R2R prompts."""
+    pass
+
+
+@prompts.command()
+@click.argument("directory", type=click.Path(exists=True, file_okay=False))
+@click.pass_obj
+def sync(obj, directory):
+    """Sync prompt definitions from a directory of JSON or YAML files."""
+    if not isinstance(obj, R2RClient):
+        raise click.UsageError("Prompt sync requires client-server mode")
+    t0 = time.time()
+    summary = obj.sync_prompts(directory)
+    t1 = time.time()
+    click.echo(f"Time taken to sync prompts: {t1-t0:.2f} seconds")
+    for status, names in summary.items():
+        for name in names:
+            click.echo(f"{status}: {name}")
+
+
+@prompts.command(name="list")
+@click.pass_obj
+def list_prompts(obj):
+    """List the names of all prompts."""
+    for name in sorted(unwrap_results(obj.app_settings())["prompts"]):
+        click.echo(name)
+
+
+@prompts.command(name="get")
+@click.argument("name")
+@click.pass_obj
+def get_prompt(obj, name):
+    """Show a prompt's template and input types."""
+    prompts = unwrap_results(obj.app_settings())["prompts"]
+    if name not in prompts:
+        raise click.BadParameter(
+            f"Prompt '{name}' not found", param_hint="name"
+        )
+    click.echo(f"Input types: {prompts[name]['input_types']}")
+    click.echo(prompts[name]["template"])
+
+
+@prompts.command(name="set")
+@click.argument("name")
+@click.option(
+    "--template",
+    "template_file",
+    type=click.File("r"),
+    help="File containing the prompt template",
+)
+@click.option("--input-types", type=JSON, help="Input types as JSON")
+@click.pass_obj
+def set_prompt(obj, name, template_file, input_types):
+    """Update a prompt's template and/or input types."""
+    if not template_file and not input_types:
+        raise click.UsageError("Provide --template and/or --input-types")
+    response = obj.update_prompt(
+        name,
+        template=template_file.read() if template_file else None,
+        input_types=input_types,
+    )
+    click.echo(unwrap_results(response))
+
+
 def main():
     cli()
 
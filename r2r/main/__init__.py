@@ -1,5 +1,27 @@
 from .abstractions import R2RPipelines, R2RProviders
-from .api.client import R2RClient
+from .api.client import (
+    AppSettings,
+    CircuitBreaker,
+    DocumentChunk,
+    FieldError,
+    FileSpec,
+    R2RCircuitOpenError,
+    R2RClient,
+    R2RConnectionError,
+    R2RDNSError,
+    R2RHTTPError,
+    R2RTimeoutError,
+    R2RTransportError,
+    R2RValidationError,
+    RAGStreamParser,
+    RAGStreamResult,
+    RateLimiter,
+    SearchCache,
+    file_specs_from_directory,
+    group_results_by_document,
+    merge_search_results,
+    unwrap_results,
+)
 from .api.requests import (
     R2RAnalyticsRequest,
     R2RDeleteRequest,
@@ -47,6 +69,26 @@ __all__ = [
     "R2REngine",
     "R2RConfig",
     "R2RClient",
+    "AppSettings",
+    "R2RHTTPError",
+    "R2RValidationError",
+    "R2RTransportError",
+    "R2RTimeoutError",
+    "R2RConnectionError",
+    "R2RDNSError",
+    "R2RCircuitOpenError",
+    "RateLimiter",
+    "RAGStreamParser",
+    "RAGStreamResult",
+    "SearchCache",
+    "CircuitBreaker",
+    "DocumentChunk",
+    "FieldError",
+    "FileSpec",
+    "file_specs_from_directory",
+    "group_results_by_document",
+    "merge_search_results",
+    "unwrap_results",
     "R2RPipeFactory",
     "R2RPipelineFactory",
     "R2RProviderFactory",
@@ -1,5 +1,6 @@
 from typing import Any, Optional
 
+import openai
 from pydantic import BaseModel
 
 from r2r.base import (
@@ -64,4 +65,15 @@ class R2RException(Exception):
     ):
         self.message = message
         self.status_code = status_code
+        self.detail = detail
         super().__init__(self.message)
+
+
+def is_rate_limit_error(e: Exception) -> bool:
+    """Whether `e` came from an embedding or LLM provider rate limit."""
+    # litellm's RateLimitError subclasses openai's, other provider errors
+    # carry the HTTP status code they failed with
+    return (
+        isinstance(e, openai.RateLimitError)
+        or getattr(e, "status_code", None) == 429
+    )
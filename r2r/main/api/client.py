@@ -1,23 +1,49 @@
 import asyncio
+import copy
 import functools
+import io
 import json
+import os
+import platform
+import random
 import threading
 import time
 import uuid
-from typing import Any, AsyncGenerator, Generator, Optional, Union
+from concurrent.futures import ThreadPoolExecutor
+from importlib.metadata import PackageNotFoundError, version
+from typing import (
+    Any,
+    AsyncGenerator,
+    Callable,
+    Generator,
+    Iterator,
+    Optional,
+    Union,
+)
+from urllib.parse import urlparse
 
 import fire
 import httpx
 import nest_asyncio
 import requests
+from pydantic import BaseModel
+from requests.adapters import HTTPAdapter
 
-from r2r.base import GenerationConfig, KGSearchSettings, VectorSearchSettings
+from r2r.base import (
+    DocumentType,
+    GenerationConfig,
+    KGSearchSettings,
+    VectorSearchSettings,
+    generate_id_from_label,
+)
 
+from ..assembly.config import R2RConfig
 from .requests import (
     R2RAnalyticsRequest,
     R2RDeleteRequest,
     R2RDocumentChunksRequest,
     R2RDocumentsOverviewRequest,
+    R2REvalRequest,
     R2RIngestDocumentsRequest,
     R2RIngestFilesRequest,
     R2RLogsRequest,
@@ -31,21 +57,418 @@ from .requests import (
 
 nest_asyncio.apply()
 
+try:
+    SDK_VERSION = version("r2r")
+except PackageNotFoundError:
+    SDK_VERSION = "unknown"
+
+DEFAULT_USER_AGENT = (
+    f"r2r-python-sdk/{SDK_VERSION} python/{platform.python_version()}"
+)
+
 
 class R2RHTTPError(Exception):
-    def __init__(self, status_code, error_type, message):
+    def __init__(
+        self,
+        status_code,
+        error_type,
+        message,
+        retry_after=None,
+        response=None,
+        request_id=None,
+    ):
         self.status_code = status_code
         self.error_type = error_type
         self.message = message
+        self.retry_after = retry_after
+        # The raw HTTP response, kept for debugging failed calls
+        self.response = response
+        # Matches the X-Request-ID in the server logs
+        self.request_id = request_id
         super().__init__(f"[{status_code}] {error_type}: {message}")
 
+    @property
+    def is_rate_limited(self) -> bool:
+        # Provider rate limits (e.g. embeddings) may surface as other codes
+        return self.status_code == 429 or "RateLimit" in str(self.error_type)
+
+
+class FieldError(BaseModel):
+    """One entry of a FastAPI validation error's `detail` list."""
+
+    loc: list[Union[str, int]] = []
+    msg: str = ""
+    type: str = ""
+
+
+class R2RValidationError(R2RHTTPError):
+    """The server rejected the request body (HTTP 422)."""
+
+    def __init__(self, errors: list[FieldError], **kwargs):
+        self.errors = errors
+        message = "; ".join(
+            f"{'.'.join(str(part) for part in error.loc)}: {error.msg}"
+            for error in errors
+        )
+        super().__init__(
+            status_code=422,
+            error_type="ValidationError",
+            message=message,
+            **kwargs,
+        )
+
+
+class R2RTransportError(Exception):
+    """The request failed before any response was received from R2R."""
+
+
+class R2RTimeoutError(R2RTransportError, requests.Timeout):
+    pass
+
+
+class R2RConnectionError(R2RTransportError, requests.ConnectionError):
+    pass
+
+
+class R2RDNSError(R2RConnectionError):
+    pass
+
+
+DNS_ERROR_MARKERS = (
+    "Name or service not known",
+    "nodename nor servname provided",
+    "Temporary failure in name resolution",
+    "getaddrinfo failed",
+)
+
+
+def _transport_error(error: Exception) -> Exception:
+    if isinstance(error, (requests.Timeout, httpx.TimeoutException)):
+        return R2RTimeoutError(f"Request to R2R timed out: {error}")
+    if isinstance(error, (requests.ConnectionError, httpx.ConnectError)):
+        if any(marker in str(error) for marker in DNS_ERROR_MARKERS):
+            return R2RDNSError(f"Could not resolve the R2R host: {error}")
+        return R2RConnectionError(f"Could not connect to R2R: {error}")
+    return error
+
+
+class RateLimiter:
+    """Token bucket limiting the client to `rate` requests per second."""
+
+    def __init__(self, rate: float, burst: int = 1):
+        self.rate = rate
+        self.burst = burst
+        self._tokens = float(burst)
+        self._last = time.monotonic()
+        self._lock = threading.Lock()
+
+    def acquire(self):
+        with self._lock:
+            now = time.monotonic()
+            self._tokens = min(
+                self.burst, self._tokens + (now - self._last) * self.rate
+            )
+            self._last = now
+            wait = (1 - self._tokens) / self.rate if self._tokens < 1 else 0
+            self._tokens -= 1
+        if wait:
+            time.sleep(wait)
+
+
+class R2RCircuitOpenError(Exception):
+    """The request was not sent because the circuit breaker is open."""
+
+
+class CircuitBreaker:
+    """Fails requests fast after `failure_threshold` consecutive failures.
+
+    Once `reset_timeout` seconds have passed a single probe request is let
+    through; success closes the circuit and failure restarts the cooldown.
+    """
+
+    def __init__(self, failure_threshold: int = 5, reset_timeout: float = 30):
+        self.failure_threshold = failure_threshold
+        self.reset_timeout = reset_timeout
+        self._failures = 0
+        self._opened_at: Optional[float] = None
+        self._probing = False
+        self._lock = threading.Lock()
+
+    @property
+    def state(self) -> str:
+        if self._opened_at is None:
+            return "closed"
+        if time.monotonic() - self._opened_at < self.reset_timeout:
+            return "open"
+        return "half_open"
+
+    def before_request(self):
+        with self._lock:
+            state = self.state
+            if state == "closed":
+                return
+            if state == "open" or self._probing:
+                raise R2RCircuitOpenError(
+                    f"R2R failed {self._failures} times in a row, not sending requests for up to {self.reset_timeout}s."
+                )
+            self._probing = True
+
+    def record_success(self):
+        with self._lock:
+            self._failures = 0
+            self._opened_at = None
+            self._probing = False
+
+    def record_failure(self):
+        with self._lock:
+            self._failures += 1
+            self._probing = False
+            if self._failures >= self.failure_threshold:
+                self._opened_at = time.monotonic()
+
+
+class SearchCache:
+    """In-memory cache of search responses that expire after `ttl` seconds.
+
+    Override `get` and `set` to keep responses in another store.
+    """
+
+    def __init__(self, ttl: float = 60, maxsize: int = 1024):
+        self.ttl = ttl
+        self.maxsize = maxsize
+        self._entries: dict[str, tuple[float, Any]] = {}
+        self._lock = threading.Lock()
+
+    def get(self, key: str) -> Optional[Any]:
+        with self._lock:
+            entry = self._entries.get(key)
+            if entry is None:
+                return None
+            expires_at, value = entry
+            if time.monotonic() >= expires_at:
+                del self._entries[key]
+                return None
+            return value
+
+    def set(self, key: str, value: Any) -> None:
+        with self._lock:
+            if len(self._entries) >= self.maxsize:
+                # Drop the entry closest to expiring to make room
+                oldest = min(self._entries, key=lambda k: self._entries[k][0])
+                del self._entries[oldest]
+            self._entries[key] = (time.monotonic() + self.ttl, value)
+
+
+def unwrap_results(response: Any) -> Any:
+    # Server responses wrap their payload as `{"results": ...}`, while the
+    # in-process R2R object returns the payload directly.
+    if isinstance(response, dict) and "results" in response:
+        return response["results"]
+    return response
+
+
+def _result_score(result: dict[str, Any]) -> float:
+    # A reranker's score outranks the vector similarity it was computed from
+    if result.get("rerank_score") is not None:
+        return result["rerank_score"]
+    return result["score"]
+
+
+def group_results_by_document(
+    vector_search_results: list[dict[str, Any]]
+) -> list[dict[str, Any]]:
+    """Collapse chunk-level search results into one entry per document.
+
+    Each entry holds the document ID, its best chunk score and the matching
+    chunks, and entries are ordered by best score. Chunks are scored by their
+    `rerank_score` when the search was reranked.
+    """
+    documents: dict[str, dict[str, Any]] = {}
+    for result in vector_search_results:
+        document_id = str(result["metadata"].get("document_id"))
+        document = documents.setdefault(
+            document_id,
+            {
+                "document_id": document_id,
+                "score": _result_score(result),
+                "chunks": [],
+            },
+        )
+        document["score"] = max(document["score"], _result_score(result))
+        document["chunks"].append(result)
+    return sorted(
+        documents.values(),
+        key=lambda document: document["score"],
+        reverse=True,
+    )
+
+
+def merge_search_results(*responses: Any) -> dict[str, Any]:
+    """Merge several search responses into one deduplicated result set.
+
+    Vector results are deduplicated by chunk ID keeping the highest score and
+    re-sorted by score, using `rerank_score` when the search was reranked; KG
+    results are concatenated.
+    """
+    vector_results: dict[str, dict[str, Any]] = {}
+    kg_results: list = []
+    for response in responses:
+        results = unwrap_results(response)
+        for result in results.get("vector_search_results") or []:
+            existing = vector_results.get(str(result["id"]))
+            if existing is None or _result_score(result) > _result_score(
+                existing
+            ):
+                vector_results[str(result["id"])] = result
+        kg_results.extend(results.get("kg_search_results") or [])
+    return {
+        "vector_search_results": sorted(
+            vector_results.values(),
+            key=_result_score,
+            reverse=True,
+        ),
+        "kg_search_results": kg_results,
+    }
+
+
+# The built-in presets live in config.json alongside the other defaults
+R2RConfig.register_generation_presets(
+    R2RConfig.load_default_config().get("generation_presets", {})
+)
+
+
+def _to_generation_config(
+    config: Optional[Union[GenerationConfig, dict[str, Any], str]]
+) -> Optional[GenerationConfig]:
+    # Allow partial overrides, e.g. `{"model": "gpt-4o-mini"}`, or a preset
+    # name registered with `GenerationConfig.register_preset`
+    if isinstance(config, dict):
+        return GenerationConfig(**config)
+    if isinstance(config, str):
+        return GenerationConfig.from_preset(config)
+    return config
 
-def handle_request_error(response):
+
+def _retry_after(response) -> Optional[float]:
+    value = response.headers.get("Retry-After")
+    try:
+        return float(value) if value is not None else None
+    except ValueError:
+        return None
+
+
+def _rate_limit_headers(response) -> dict[str, str]:
+    return {
+        key: value
+        for key, value in response.headers.items()
+        if key.lower().startswith("x-ratelimit")
+        or key.lower() == "retry-after"
+    }
+
+
+def _file_positions(files) -> Optional[list[tuple[Any, int]]]:
+    # A retry resends the same file objects, which the first attempt read to
+    # the end, so each one is rewound; None if any of them cannot be
+    positions = []
+    for _, file_tuple in files or []:
+        file_obj = file_tuple[1]
+        if not (hasattr(file_obj, "seekable") and file_obj.seekable()):
+            return None
+        positions.append((file_obj, file_obj.tell()))
+    return positions
+
+
+def _backoff_delay(attempt: int, cap: float = 30.0) -> float:
+    # Exponential backoff with full jitter, used when there's no Retry-After
+    return random.uniform(0, min(cap, 2**attempt))
+
+
+def _request_id(response) -> Optional[str]:
+    # Prefer the ID the server echoed back, else the one that was sent
+    request = getattr(response, "request", None)
+    return response.headers.get("X-Request-ID") or (
+        request.headers.get("X-Request-ID") if request is not None else None
+    )
+
+
+def _body_snippet(response, limit: int = 500) -> str:
+    text = (response.text or "").strip()
+    if not text:
+        return "<empty response body>"
+    return text if len(text) <= limit else f"{text[:limit]}..."
+
+
+class _UploadFile:
+    """A file part that is only open while its contents are being read.
+
+    `requests` reads the parts one after another while it builds the
+    multipart body, so a batch upload holds one file descriptor at a time.
+    Every read starts from the beginning, which also makes retries safe.
+    """
+
+    def __init__(self, path: str):
+        self.path = path
+
+    def read(self) -> bytes:
+        with open(self.path, "rb") as f:
+            return f.read()
+
+    def seekable(self) -> bool:
+        return True
+
+    def tell(self) -> int:
+        return 0
+
+    def seek(self, offset: int, whence: int = os.SEEK_SET) -> int:
+        return 0
+
+
+def _upload_files(
+    paths: list[str],
+    file_field: str,
+    content_types: Optional[list[Optional[str]]] = None,
+) -> list:
+    # Fail before sending anything rather than halfway through the body
+    if content_types is not None and len(content_types) != len(paths):
+        raise ValueError(
+            f"Expected one content type per file, got {len(content_types)} for {len(paths)} file(s)."
+        )
+    for path in paths:
+        if not os.path.isfile(path):
+            raise FileNotFoundError(f"No such file: '{path}'")
+    return [
+        (
+            file_field,
+            (
+                path,
+                _UploadFile(path),
+                (content_types[i] if content_types else None)
+                or "application/octet-stream",
+            ),
+        )
+        for i, path in enumerate(paths)
+    ]
+
+
+def handle_request_error(
+    response, json_loads: Callable[[str], Any] = json.loads
+):
     if response.status_code >= 400:
         try:
-            error_content = response.json()
+            error_content = json_loads(response.text)
             if isinstance(error_content, dict) and "detail" in error_content:
                 detail = error_content["detail"]
+                if response.status_code == 422 and isinstance(detail, list):
+                    raise R2RValidationError(
+                        errors=[
+                            FieldError(**error)
+                            for error in detail
+                            if isinstance(error, dict)
+                        ],
+                        retry_after=_retry_after(response),
+                        response=response,
+                        request_id=_request_id(response),
+                    )
                 if isinstance(detail, dict):
                     message = detail.get("message", str(response.text))
                     error_type = detail.get("error_type", "UnknownError")
@@ -55,17 +478,223 @@ def handle_request_error(response):
             else:
                 message = str(error_content)
                 error_type = "UnknownError"
-        except json.JSONDecodeError:
-            message = response.text
+        except ValueError:
+            message = _body_snippet(response)
             error_type = "UnknownError"
 
         raise R2RHTTPError(
             status_code=response.status_code,
             error_type=error_type,
             message=message,
+            retry_after=_retry_after(response),
+            response=response,
+            request_id=_request_id(response),
         )
 
 
+class RAGStreamParser:
+    """Splits a streamed RAG response into its search and completion parts.
+
+    The stream is framed as `<search>...</search><completion>...</completion>`
+    and markers may be split across chunks, so partial markers are held back
+    until the next chunk arrives.
+    """
+
+    MARKERS = {
+        "<search>": "search",
+        "</search>": None,
+        "<completion>": "completion",
+        "</completion>": None,
+    }
+
+    def __init__(self):
+        self.section: Optional[str] = None
+        self._buffer = ""
+
+    def feed(self, chunk: str) -> list[tuple[str, str]]:
+        events: list[tuple[str, str]] = []
+        self._buffer += chunk
+        while True:
+            matches = [
+                (self._buffer.find(marker), marker)
+                for marker in self.MARKERS
+                if marker in self._buffer
+            ]
+            if not matches:
+                break
+            index, marker = min(matches)
+            self._emit(events, self._buffer[:index])
+            self.section = self.MARKERS[marker]
+            self._buffer = self._buffer[index + len(marker) :]
+
+        cut = len(self._buffer)
+        last_open = self._buffer.rfind("<")
+        if last_open != -1 and any(
+            marker.startswith(self._buffer[last_open:])
+            for marker in self.MARKERS
+        ):
+            cut = last_open
+        self._emit(events, self._buffer[:cut])
+        self._buffer = self._buffer[cut:]
+        return events
+
+    def _emit(self, events: list[tuple[str, str]], text: str) -> None:
+        if text and self.section:
+            events.append((self.section, text))
+
+
+class RAGStreamResult:
+    """Iterates over streamed completion text and keeps the final result.
+
+    Once the stream is exhausted, `completion` holds the assembled answer and
+    `search_results` the search results that were streamed ahead of it.
+    Use `events()` instead to receive the search results as soon as the
+    search section closes, before any completion text.
+    """
+
+    def __init__(
+        self,
+        chunks: Iterator[str],
+        json_loads: Callable[[str], Any] = json.loads,
+    ):
+        self._chunks = chunks
+        self._json_loads = json_loads
+        self._search = ""
+        self.completion = ""
+        self.search_results: list[dict] = []
+        self.done = False
+
+    def __iter__(self) -> Iterator[str]:
+        for kind, value in self.events():
+            if kind == "completion":
+                yield value
+
+    def events(self) -> Iterator[tuple[str, Any]]:
+        """Yield `("search_results", list)` once, then `("completion", str)`
+        deltas as the answer is generated."""
+        parser = RAGStreamParser()
+        sources_sent = False
+        for chunk in self._chunks:
+            for section, text in parser.feed(chunk):
+                if section == "search":
+                    self._search += text
+                    continue
+                if not sources_sent:
+                    sources_sent = True
+                    yield "search_results", self._parse_search_results()
+                self.completion += text
+                yield "completion", text
+            if not sources_sent and parser.section not in (None, "search"):
+                sources_sent = True
+                yield "search_results", self._parse_search_results()
+        if not sources_sent:
+            yield "search_results", self._parse_search_results()
+        self.done = True
+
+    def _parse_search_results(self) -> list[dict]:
+        # Each streamed search result is a JSON-encoded JSON string
+        if self._search.strip():
+            self.search_results = [
+                self._json_loads(result)
+                for result in self._json_loads(f"[{self._search}]")
+            ]
+        return self.search_results
+
+
+class DocumentChunk(BaseModel):
+    """A stored chunk of a document, as returned by `document_chunks`."""
+
+    fragment_id: Optional[uuid.UUID] = None
+    document_id: uuid.UUID
+    extraction_id: Optional[uuid.UUID] = None
+    chunk_order: int = 0
+    text: str = ""
+    metadata: dict = {}
+
+    @classmethod
+    def from_metadata(cls, metadata: dict) -> "DocumentChunk":
+        # Any keys that aren't chunk fields are the document's own metadata
+        metadata = dict(metadata)
+        return cls(
+            fragment_id=metadata.pop("fragment_id", None),
+            document_id=metadata.pop("document_id"),
+            extraction_id=metadata.pop("extraction_id", None),
+            chunk_order=metadata.pop("chunk_order", 0),
+            text=metadata.pop("text", ""),
+            metadata=metadata,
+        )
+
+
+class AppSettings(BaseModel):
+    """The commonly used parts of `app_settings`, the full payload is `raw`."""
+
+    embedding_provider: Optional[str] = None
+    embedding_model: Optional[str] = None
+    embedding_dimension: Optional[int] = None
+    completion_provider: Optional[str] = None
+    chunk_size: Optional[int] = None
+    chunk_overlap: Optional[int] = None
+    excluded_parsers: list[str] = []
+    prompt_names: list[str] = []
+    raw: dict = {}
+
+    @classmethod
+    def from_settings(
+        cls, settings: dict, json_loads: Callable[[str], Any] = json.loads
+    ) -> "AppSettings":
+        config = settings.get("config") or {}
+        if isinstance(config, str):
+            config = json_loads(config)
+        embedding = config.get("embedding") or {}
+        text_splitter = embedding.get("text_splitter") or {}
+        return cls(
+            embedding_provider=embedding.get("provider"),
+            embedding_model=embedding.get("base_model"),
+            embedding_dimension=embedding.get("base_dimension"),
+            completion_provider=(config.get("completions") or {}).get(
+                "provider"
+            ),
+            chunk_size=text_splitter.get("chunk_size"),
+            chunk_overlap=text_splitter.get("chunk_overlap"),
+            excluded_parsers=(config.get("ingestion") or {}).get(
+                "excluded_parsers"
+            )
+            or [],
+            prompt_names=sorted(settings.get("prompts") or {}),
+            raw={**settings, "config": config},
+        )
+
+
+class FileSpec(BaseModel):
+    """A file to ingest together with its own metadata and identifiers."""
+
+    path: str
+    metadata: dict = {}
+    document_id: Optional[Union[uuid.UUID, str]] = None
+    user_id: Optional[Union[uuid.UUID, str]] = None
+
+
+def file_specs_from_directory(
+    directory: str,
+    metadata_fn: Optional[Callable[[str], dict]] = None,
+    recursive: bool = True,
+) -> list[FileSpec]:
+    """Build a `FileSpec` for every file under `directory`.
+
+    `metadata_fn` receives each file path and returns that file's metadata.
+    """
+    paths = []
+    for root, dirs, files in os.walk(directory):
+        dirs.sort()
+        paths.extend(os.path.join(root, file) for file in sorted(files))
+        if not recursive:
+            break
+    return [
+        FileSpec(path=path, metadata=metadata_fn(path) if metadata_fn else {})
+        for path in paths
+    ]
+
+
 def monitor_request(func):
     @functools.wraps(func)
     def wrapper(*args, monitor=False, **kwargs):
@@ -104,15 +733,198 @@ def monitor_request(func):
 
 
 class R2RClient:
-    def __init__(self, base_url: str, prefix: str = "/v1"):
-        self.base_url = base_url
+    def __init__(
+        self,
+        base_url: str,
+        prefix: str = "/v1",
+        timeout: Optional[float] = None,
+        rate_limiter: Optional[RateLimiter] = None,
+        max_rate_limit_retries: int = 0,
+        on_request: Optional[
+            Callable[[str, str, Optional[int], float], None]
+        ] = None,
+        json_dumps: Callable[[Any], str] = json.dumps,
+        json_loads: Callable[[str], Any] = json.loads,
+        metadata_schema: Optional[dict[str, type]] = None,
+        session: Optional[requests.Session] = None,
+        pool_maxsize: int = 10,
+        user_agent: Optional[str] = None,
+        circuit_breaker: Optional[CircuitBreaker] = None,
+        search_cache: Optional[SearchCache] = None,
+        request_id_factory: Callable[[], str] = lambda: str(uuid.uuid4()),
+    ):
+        self.set_base_url(base_url)
         self.prefix = prefix
+        self.timeout = timeout
+        self.rate_limiter = rate_limiter
+        self.circuit_breaker = circuit_breaker
+        self.search_cache = search_cache
+        self.request_id_factory = request_id_factory
+        self.last_request_id: Optional[str] = None
+        self._embedding_info: Optional[dict] = None
+        self.max_rate_limit_retries = max_rate_limit_retries
+        self.last_rate_limit: dict[str, str] = {}
+        self.last_response: Optional[requests.Response] = None
+        self.on_request = on_request
+        # All request and response bodies go through this codec
+        self.json_dumps = json_dumps
+        self.json_loads = json_loads
+        self.metadata_schema = metadata_schema
+        # Reuse connections across calls instead of reconnecting every time
+        if session is None:
+            session = requests.Session()
+            adapter = HTTPAdapter(
+                pool_connections=pool_maxsize, pool_maxsize=pool_maxsize
+            )
+            session.mount("http://", adapter)
+            session.mount("https://", adapter)
+        self.session = session
+        # Callers append their own product token, e.g. "my-app/1.2"
+        self.user_agent = (
+            f"{DEFAULT_USER_AGENT} {user_agent}"
+            if user_agent
+            else DEFAULT_USER_AGENT
+        )
+
+    def set_base_url(self, base_url: str) -> None:
+        parsed = urlparse(base_url)
+        if parsed.scheme not in ("http", "https") or not parsed.netloc:
+            raise ValueError(
+                f"Invalid base URL '{base_url}': expected a URL such as 'http://localhost:8000'."
+            )
+        self.base_url = base_url.rstrip("/")
+
+    def _url(self, endpoint: str) -> str:
+        # Proxied deployments may set a prefix such as "/r2r/v1/", so stray
+        # slashes are trimmed rather than doubled
+        parts = [self.prefix.strip("/"), endpoint.lstrip("/")]
+        return "/".join([self.base_url] + [part for part in parts if part])
+
+    @classmethod
+    def from_env(cls, **kwargs) -> "R2RClient":
+        base_url = kwargs.pop("base_url", None) or os.getenv("R2R_BASE_URL")
+        if not base_url:
+            raise ValueError(
+                "R2R_BASE_URL must be set to create a client from the environment."
+            )
+        kwargs.setdefault("prefix", os.getenv("R2R_PREFIX", "/v1"))
+        if "timeout" not in kwargs and os.getenv("R2R_TIMEOUT"):
+            kwargs["timeout"] = float(os.environ["R2R_TIMEOUT"])
+        return cls(base_url, **kwargs)
+
+    def with_timeout(self, timeout: Optional[float]) -> "R2RClient":
+        # Returns a copy so the override only applies to calls made on it
+        client = copy.copy(self)
+        client.timeout = timeout
+        return client
+
+    def _send(self, method, endpoint, url, **kwargs) -> requests.Response:
+        if self.circuit_breaker:
+            self.circuit_breaker.before_request()
+        try:
+            response = self._send_uncounted(method, endpoint, url, **kwargs)
+        except BaseException:
+            # Any error, not only a transport one, must end a half-open probe
+            if self.circuit_breaker:
+                self.circuit_breaker.record_failure()
+            raise
+        if self.circuit_breaker:
+            # Only server-side failures count towards opening the circuit
+            if response.status_code >= 500:
+                self.circuit_breaker.record_failure()
+            else:
+                self.circuit_breaker.record_success()
+        self.last_response = response
+        return response
+
+    def _send_uncounted(
+        self, method, endpoint, url, **kwargs
+    ) -> requests.Response:
+        if self.rate_limiter:
+            self.rate_limiter.acquire()
+        status_code = None
+        t0 = time.monotonic()
+        try:
+            response = self.session.request(method, url, **kwargs)
+            status_code = response.status_code
+        except (requests.Timeout, requests.ConnectionError) as e:
+            raise _transport_error(e) from e
+        finally:
+            # `status_code` stays None when the request never got a response
+            if self.on_request:
+                self.on_request(
+                    method, endpoint, status_code, time.monotonic() - t0
+                )
+        return response
 
     def _make_request(self, method, endpoint, **kwargs):
-        url = f"{self.base_url}{self.prefix}/{endpoint}"
-        response = requests.request(method, url, **kwargs)
-        handle_request_error(response)
-        return response.json()
+        url = self._url(endpoint)
+        kwargs.setdefault("timeout", self.timeout)
+        self.last_request_id = self.request_id_factory()
+        kwargs["headers"] = {
+            "User-Agent": self.user_agent,
+            "X-Request-ID": self.last_request_id,
+            **kwargs.get("headers", {}),
+        }
+        if "json" in kwargs:
+            kwargs["data"] = self.json_dumps(kwargs.pop("json"))
+            kwargs["headers"] = {
+                "Content-Type": "application/json",
+                **kwargs["headers"],
+            }
+        file_positions = _file_positions(kwargs.get("files"))
+        # Uploads that cannot be rewound, such as a pipe or socket, are
+        # only sent once
+        max_retries = (
+            self.max_rate_limit_retries if file_positions is not None else 0
+        )
+        for attempt in range(max_retries + 1):
+            for file_obj, position in file_positions or []:
+                file_obj.seek(position)
+            response = self._send(method, endpoint, url, **kwargs)
+            self.last_rate_limit = _rate_limit_headers(response)
+            if response.status_code != 429 or attempt == max_retries:
+                break
+            retry_after = _retry_after(response)
+            time.sleep(
+                retry_after
+                if retry_after is not None
+                else _backoff_delay(attempt)
+            )
+        handle_request_error(response, self.json_loads)
+        try:
+            return self.json_loads(response.text)
+        except ValueError:
+            raise R2RHTTPError(
+                status_code=response.status_code,
+                error_type="InvalidResponse",
+                message=f"Expected a JSON body, got: {_body_snippet(response)}",
+                response=response,
+                request_id=_request_id(response),
+            )
+
+    def request(
+        self,
+        method: str,
+        endpoint: str,
+        json: Optional[Any] = None,
+        params: Optional[dict] = None,
+    ) -> Any:
+        """Call an endpoint that the client does not wrap yet.
+
+        Retries, request IDs and error handling match the wrapped methods,
+        and the `results` envelope is stripped from the response.
+        """
+        kwargs = {}
+        if json is not None:
+            kwargs["json"] = json
+        if params is not None:
+            kwargs["params"] = params
+        return unwrap_results(
+            self._make_request(
+                method.upper(), endpoint.lstrip("/"), **kwargs
+            )
+        )
 
     def health(self) -> dict:
         return self._make_request("GET", "health")
@@ -127,9 +939,87 @@ class R2RClient:
             name=name, template=template, input_types=input_types
         )
         return self._make_request(
-            "POST", "update_prompt", json=json.loads(request.json())
+            "POST", "update_prompt", json=request.model_dump(mode="json")
         )
 
+    def _server_config(self) -> dict:
+        config = unwrap_results(self.app_settings())["config"]
+        if isinstance(config, str):
+            config = self.json_loads(config)
+        return config
+
+    def embedding_info(self, refresh: bool = False) -> dict:
+        # Ingestion and search must agree on these, so they rarely change
+        if self._embedding_info is None or refresh:
+            embedding = self._server_config().get("embedding", {})
+            self._embedding_info = {
+                "provider": embedding.get("provider"),
+                "model": embedding.get("base_model"),
+                "dimension": embedding.get("base_dimension"),
+            }
+        return self._embedding_info
+
+    def supported_file_types(self) -> list[str]:
+        config = self._server_config()
+        excluded = config.get("ingestion", {}).get("excluded_parsers") or []
+        return [
+            doc_type.value
+            for doc_type in DocumentType
+            if doc_type.value not in excluded
+        ]
+
+    def sync_prompts(self, directory: str) -> dict:
+        # Prompt files use the same format as `prompts/local/defaults.jsonl`,
+        # YAML files are read only when PyYAML is installed
+        prompts = []
+        skipped = []
+        for file_name in sorted(os.listdir(directory)):
+            file_path = os.path.join(directory, file_name)
+            extension = os.path.splitext(file_name)[1].lower()
+            if extension in (".yaml", ".yml"):
+                try:
+                    import yaml
+                except ImportError:
+                    skipped.append(file_name)
+                    continue
+            elif extension not in (".json", ".jsonl"):
+                skipped.append(file_name)
+                continue
+            with open(file_path, "r") as f:
+                if extension == ".jsonl":
+                    prompts.extend(
+                        self.json_loads(line) for line in f if line.strip()
+                    )
+                    continue
+                if extension == ".json":
+                    data = self.json_loads(f.read())
+                else:
+                    data = yaml.safe_load(f)
+                prompts.extend(data if isinstance(data, list) else [data])
+
+        existing = unwrap_results(self.app_settings())["prompts"]
+        summary: dict[str, list[str]] = {
+            "updated": [],
+            "unchanged": [],
+            "missing": [],
+            "skipped": skipped,
+        }
+        for prompt in prompts:
+            name = prompt["name"]
+            template = prompt.get("template")
+            input_types = prompt.get("input_types", {})
+            if name not in existing:
+                summary["missing"].append(name)
+            elif (
+                existing[name]["template"] == template
+                and existing[name]["input_types"] == input_types
+            ):
+                summary["unchanged"].append(name)
+            else:
+                self.update_prompt(name, template, input_types)
+                summary["updated"].append(name)
+        return summary
+
     @monitor_request
     def ingest_documents(
         self, documents: list[dict], versions: Optional[list[str]] = None
@@ -138,7 +1028,7 @@ class R2RClient:
             documents=documents, versions=versions
         )
         return self._make_request(
-            "POST", "ingest_documents", json=json.loads(request.json())
+            "POST", "ingest_documents", json=request.model_dump(mode="json")
         )
 
     @monitor_request
@@ -149,11 +1039,32 @@ class R2RClient:
         document_ids: Optional[list[Union[uuid.UUID, str]]] = None,
         user_ids: Optional[list[Union[uuid.UUID, str]]] = None,
         versions: Optional[list[str]] = None,
+        file_field: str = "files",
+        extra_form_data: Optional[dict[str, str]] = None,
+        max_retries: int = 0,
+        validate_file_types: bool = False,
+        content_types: Optional[list[Optional[str]]] = None,
     ) -> dict:
-        files_to_upload = [
-            ("files", (file, open(file, "rb"), "application/octet-stream"))
-            for file in file_paths
-        ]
+        if content_types is not None and len(content_types) != len(
+            file_paths
+        ):
+            raise ValueError(
+                f"Expected one content type per file, got {len(content_types)} for {len(file_paths)} file(s)."
+            )
+        if validate_file_types:
+            supported = self.supported_file_types()
+            unsupported = sorted(
+                {
+                    os.path.splitext(path)[1] or path
+                    for i, path in enumerate(file_paths)
+                    if not (content_types and content_types[i])
+                    and os.path.splitext(path)[1][1:].lower() not in supported
+                }
+            )
+            if unsupported:
+                raise ValueError(
+                    f"Unsupported file type(s) {unsupported}, expected one of {supported}."
+                )
         request = R2RIngestFilesRequest(
             metadatas=metadatas,
             document_ids=(
@@ -166,19 +1077,170 @@ class R2RClient:
             ),
             versions=versions,
         )
-        try:
-            return self._make_request(
-                "POST",
-                "ingest_files",
-                data={
-                    k: json.dumps(v)
-                    for k, v in json.loads(request.json()).items()
-                },
-                files=files_to_upload,
+        data = {
+            **{
+                k: self.json_dumps(v)
+                for k, v in request.model_dump(mode="json").items()
+            },
+            **(extra_form_data or {}),
+        }
+        # The server falls back to the content type when the extension is
+        # missing or unknown
+        files_to_upload = _upload_files(file_paths, file_field, content_types)
+        for attempt in range(max_retries + 1):
+            try:
+                return self._make_request(
+                    "POST",
+                    "ingest_files",
+                    data=data,
+                    files=files_to_upload,
+                )
+            except (requests.ConnectionError, requests.Timeout):
+                if attempt == max_retries:
+                    raise
+
+    @monitor_request
+    def ingest_text(
+        self,
+        text: str,
+        name: str,
+        metadata: Optional[dict] = None,
+        document_id: Optional[Union[uuid.UUID, str]] = None,
+        user_id: Optional[Union[uuid.UUID, str]] = None,
+        version: Optional[str] = None,
+    ) -> dict:
+        # The server picks a parser from the file extension, default to text
+        file_name = name if os.path.splitext(name)[1] else f"{name}.txt"
+        return self._ingest_file_object(
+            file_name,
+            io.BytesIO(text.encode("utf-8")),
+            "application/octet-stream",
+            metadata={"title": name, **(metadata or {})},
+            document_id=document_id,
+            user_id=user_id,
+            version=version,
+        )
+
+    def ingest_urls(
+        self,
+        urls: list[str],
+        metadatas: Optional[list[dict]] = None,
+        user_ids: Optional[list[Optional[Union[uuid.UUID, str]]]] = None,
+    ) -> list[dict]:
+        # Downloads are read into memory one at a time, since requests builds
+        # the multipart body in full anyway, and one bad URL only fails its
+        # own entry
+        results = []
+        for i, url in enumerate(urls):
+            metadata = {
+                "source_url": url,
+                **(metadatas[i] if metadatas else {}),
+            }
+            try:
+                # Not `self.session`, which may carry credentials meant for R2R
+                download = requests.get(
+                    url,
+                    timeout=self.timeout,
+                    headers={"User-Agent": self.user_agent},
+                )
+                download.raise_for_status()
+                # Redirects are followed, so name it after the final URL
+                file_name = (
+                    os.path.basename(urlparse(download.url).path)
+                    or "index.html"
+                )
+                content_type = download.headers.get(
+                    "Content-Type", "application/octet-stream"
+                )
+                # A seekable body lets a rate-limited upload be resent
+                response = self._ingest_file_object(
+                    file_name,
+                    io.BytesIO(download.content),
+                    content_type,
+                    metadata=metadata,
+                    user_id=user_ids[i] if user_ids else None,
+                )
+                results.append({"url": url, **response})
+            except (
+                requests.RequestException,
+                R2RHTTPError,
+                R2RCircuitOpenError,
+            ) as e:
+                results.append({"url": url, "error": e})
+        return results
+
+    def _ingest_file_object(
+        self,
+        file_name: str,
+        file_obj: Any,
+        content_type: str,
+        metadata: dict,
+        document_id: Optional[Union[uuid.UUID, str]] = None,
+        user_id: Optional[Union[uuid.UUID, str]] = None,
+        version: Optional[str] = None,
+    ) -> dict:
+        request = R2RIngestFilesRequest(
+            metadatas=[metadata],
+            document_ids=[str(document_id)] if document_id else None,
+            user_ids=[str(user_id)] if user_id else None,
+            versions=[version] if version else None,
+        )
+        return self._make_request(
+            "POST",
+            "ingest_files",
+            data={
+                k: self.json_dumps(v)
+                for k, v in request.model_dump(mode="json").items()
+            },
+            files=[("files", (file_name, file_obj, content_type))],
+        )
+
+    def ingest_file_specs(self, specs: list[FileSpec], **kwargs) -> dict:
+        # Mirror the server's default ID so specs without one can be mixed in
+        document_ids = [
+            spec.document_id
+            or generate_id_from_label(
+                spec.metadata.get("title") or os.path.basename(spec.path)
             )
-        finally:
-            for _, file_tuple in files_to_upload:
-                file_tuple[1].close()
+            for spec in specs
+        ]
+        return self.ingest_files(
+            file_paths=[spec.path for spec in specs],
+            metadatas=[spec.metadata for spec in specs],
+            document_ids=document_ids,
+            user_ids=(
+                [spec.user_id for spec in specs]
+                if any(spec.user_id for spec in specs)
+                else None
+            ),
+            **kwargs,
+        )
+
+    def ingest_file_specs_with_progress(
+        self, specs: list[FileSpec], **kwargs
+    ) -> Iterator[dict]:
+        # Ingestion finishes within its request, so progress is reported per
+        # file by ingesting the specs one at a time
+        total = len(specs)
+        for index, spec in enumerate(specs):
+            event = {"path": spec.path, "total": total}
+            yield {
+                **event,
+                "stage": "ingesting",
+                "completed": index,
+                "percent": 100.0 * index / total,
+            }
+            try:
+                event["result"] = self.ingest_file_specs([spec], **kwargs)
+                event["stage"] = "done"
+            except (R2RHTTPError, R2RTransportError) as e:
+                event["error"] = e
+                event["stage"] = "failed"
+            yield {
+                **event,
+                "completed": index + 1,
+                "percent": 100.0 * (index + 1) / total,
+            }
 
     @monitor_request
     def update_documents(
@@ -191,7 +1253,7 @@ class R2RClient:
             documents=documents, versions=versions, metadatas=metadatas
         )
         return self._make_request(
-            "POST", "update_documents", json=json.loads(request.json())
+            "POST", "update_documents", json=request.model_dump(mode="json")
         )
 
     @monitor_request
@@ -200,28 +1262,64 @@ class R2RClient:
         files: list[str],
         document_ids: list[str],
         metadatas: Optional[list[dict]] = None,
+        file_field: str = "files",
+        extra_form_data: Optional[dict[str, str]] = None,
+        content_types: Optional[list[Optional[str]]] = None,
     ) -> dict:
-        files_to_upload = [
-            ("files", (file, open(file, "rb"), "application/octet-stream"))
-            for file in files
-        ]
         request = R2RUpdateFilesRequest(
             metadatas=metadatas,
             document_ids=document_ids,
         )
-        try:
-            return self._make_request(
-                "POST",
-                "update_files",
-                data={
-                    k: json.dumps(v)
-                    for k, v in json.loads(request.json()).items()
+        return self._make_request(
+            "POST",
+            "update_files",
+            data={
+                **{
+                    k: self.json_dumps(v)
+                    for k, v in request.model_dump(mode="json").items()
                 },
-                files=files_to_upload,
-            )
-        finally:
-            for _, file_tuple in files_to_upload:
-                file_tuple[1].close()
+                **(extra_form_data or {}),
+            },
+            files=_upload_files(files, file_field, content_types),
+        )
+
+    def update_file_specs(self, specs: list[FileSpec], **kwargs) -> list:
+        # One request per file, so a bad file only fails its own update
+        results = []
+        for spec in specs:
+            if not spec.document_id:
+                raise ValueError(
+                    f"FileSpec for '{spec.path}' needs a document_id to update."
+                )
+            try:
+                response = self.update_files(
+                    files=[spec.path],
+                    document_ids=[str(spec.document_id)],
+                    metadatas=[spec.metadata],
+                    **kwargs,
+                )
+                results.append(
+                    {"document_id": str(spec.document_id), **response}
+                )
+            except R2RHTTPError as e:
+                results.append(
+                    {"document_id": str(spec.document_id), "error": e}
+                )
+        return results
+
+    def _validate_filters(self, filters: Optional[dict[str, Any]]) -> None:
+        if not self.metadata_schema or not filters:
+            return
+        for key, value in filters.items():
+            if key not in self.metadata_schema:
+                raise ValueError(
+                    f"Unknown metadata field '{key}' in search filters, expected one of {sorted(self.metadata_schema)}."
+                )
+            expected_type = self.metadata_schema[key]
+            if not isinstance(value, expected_type):
+                raise ValueError(
+                    f"Search filter '{key}' must be of type {expected_type.__name__}, got {type(value).__name__} instead."
+                )
 
     def search(
         self,
@@ -231,24 +1329,103 @@ class R2RClient:
         search_limit: int = 10,
         do_hybrid_search: bool = False,
         use_kg_search: bool = False,
-        kg_agent_generation_config: Optional[GenerationConfig] = None,
+        kg_agent_generation_config: Optional[
+            Union[GenerationConfig, dict[str, Any], str]
+        ] = None,
+        document_id: Optional[Union[uuid.UUID, str]] = None,
+        include_metadata: bool = True,
+        include_text: bool = True,
+        use_cache: bool = True,
+        include_embeddings: bool = False,
+        rerank_limit: Optional[int] = None,
     ) -> dict:
+        self._validate_filters(search_filters)
+        if document_id:
+            search_filters = {
+                **(search_filters or {}),
+                "document_id": str(document_id),
+            }
+
         request = R2RSearchRequest(
             query=query,
             vector_search_settings=VectorSearchSettings(
                 use_vector_search=use_vector_search,
                 search_filters=search_filters or {},
                 search_limit=search_limit,
+                rerank_limit=rerank_limit,
                 do_hybrid_search=do_hybrid_search,
+                include_metadata=include_metadata,
+                include_text=include_text,
+                include_embeddings=include_embeddings,
             ),
             kg_search_settings=KGSearchSettings(
                 use_kg_search=use_kg_search,
-                agent_generation_config=kg_agent_generation_config,
+                # Runs independently of the model that writes the answer
+                agent_generation_config=_to_generation_config(
+                    kg_agent_generation_config
+                )
+                or GenerationConfig(),
             ),
         )
-        return self._make_request(
-            "POST", "search", json=json.loads(request.json())
+        payload = request.model_dump(mode="json")
+        if not (self.search_cache and use_cache):
+            return self._make_request("POST", "search", json=payload)
+
+        cache_key = json.dumps(
+            {**payload, "query": payload["query"].strip()}, sort_keys=True
         )
+        response = self.search_cache.get(cache_key)
+        if response is None:
+            response = self._make_request("POST", "search", json=payload)
+            self.search_cache.set(cache_key, response)
+        # Callers may mutate the response, keep the cached copy intact
+        return copy.deepcopy(response)
+
+    def search_many(
+        self,
+        queries: list[str],
+        max_workers: int = 4,
+        return_exceptions: bool = False,
+        **kwargs,
+    ) -> list:
+        return self._run_many(
+            self.search, queries, max_workers, return_exceptions, **kwargs
+        )
+
+    def rag_many(
+        self,
+        queries: list[str],
+        max_workers: int = 4,
+        return_exceptions: bool = False,
+        **kwargs,
+    ) -> list:
+        config = _to_generation_config(kwargs.get("rag_generation_config"))
+        if config and config.stream:
+            raise ValueError("rag_many does not support streaming responses.")
+        return self._run_many(
+            self.rag, queries, max_workers, return_exceptions, **kwargs
+        )
+
+    @staticmethod
+    def _run_many(
+        func: Callable[..., dict],
+        queries: list[str],
+        max_workers: int,
+        return_exceptions: bool,
+        **kwargs,
+    ) -> list:
+        # Results keep the order of `queries`, like `asyncio.gather`
+        with ThreadPoolExecutor(max_workers=max_workers) as executor:
+            futures = [
+                executor.submit(func, query, **kwargs) for query in queries
+            ]
+        results = []
+        for future in futures:
+            exception = future.exception()
+            if exception and not return_exceptions:
+                raise exception
+            results.append(exception or future.result())
+        return results
 
     def rag(
         self,
@@ -258,42 +1435,166 @@ class R2RClient:
         search_limit: int = 10,
         do_hybrid_search: bool = False,
         use_kg_search: bool = False,
-        kg_agent_generation_config: Optional[GenerationConfig] = None,
-        rag_generation_config: Optional[GenerationConfig] = None,
+        kg_agent_generation_config: Optional[
+            Union[GenerationConfig, dict[str, Any], str]
+        ] = None,
+        rag_generation_config: Optional[
+            Union[GenerationConfig, dict[str, Any], str]
+        ] = None,
+        document_id: Optional[Union[uuid.UUID, str]] = None,
+        task_prompt_override: Optional[str] = None,
+        dry_run: bool = False,
+        rerank_limit: Optional[int] = None,
     ) -> dict:
+        self._validate_filters(search_filters)
+        if document_id:
+            search_filters = {
+                **(search_filters or {}),
+                "document_id": str(document_id),
+            }
+
+        rag_generation_config = _to_generation_config(rag_generation_config)
+        if dry_run and rag_generation_config and rag_generation_config.stream:
+            raise ValueError(
+                "A dry run returns the prompt without generating, it can't be streamed."
+            )
+
         request = R2RRAGRequest(
             query=query,
             vector_search_settings=VectorSearchSettings(
                 use_vector_search=use_vector_search,
                 search_filters=search_filters or {},
                 search_limit=search_limit,
+                rerank_limit=rerank_limit,
                 do_hybrid_search=do_hybrid_search,
             ),
             kg_search_settings=KGSearchSettings(
                 use_kg_search=use_kg_search,
-                agent_generation_config=kg_agent_generation_config,
+                # Runs independently of the model that writes the answer
+                agent_generation_config=_to_generation_config(
+                    kg_agent_generation_config
+                )
+                or GenerationConfig(),
             ),
             rag_generation_config=rag_generation_config,
+            task_prompt_override=task_prompt_override,
+            dry_run=dry_run,
         )
 
-        if rag_generation_config.stream:
+        if rag_generation_config and rag_generation_config.stream:
             return self._stream_rag_sync(request)
         else:
             return self._make_request(
-                "POST", "rag", json=json.loads(request.json())
+                "POST", "rag", json=request.model_dump(mode="json")
             )
 
+    def rag_stream_text(self, query: str, **kwargs) -> Iterator[str]:
+        # Yields only the completion text, dropping the streamed search results
+        return iter(self.rag_stream_with_result(query, **kwargs))
+
+    def rag_stream_events(
+        self, query: str, **kwargs
+    ) -> Iterator[tuple[str, Any]]:
+        # Yields the search results first, then the completion deltas
+        return self.rag_stream_with_result(query, **kwargs).events()
+
+    def rag_stream_with_result(self, query: str, **kwargs) -> RAGStreamResult:
+        rag_generation_config = (
+            _to_generation_config(kwargs.pop("rag_generation_config", None))
+            or GenerationConfig()
+        ).model_copy(update={"stream": True})
+        return RAGStreamResult(
+            self.rag(
+                query, rag_generation_config=rag_generation_config, **kwargs
+            ),
+            self.json_loads,
+        )
+
     async def _stream_rag(
         self, rag_request: R2RRAGRequest
     ) -> AsyncGenerator[str, None]:
-        url = f"{self.base_url}{self.prefix}/rag"
-        async with httpx.AsyncClient() as client:
-            async with client.stream(
-                "POST", url, json=json.loads(rag_request.json())
-            ) as response:
-                handle_request_error(response)
+        url = self._url("rag")
+        client_kwargs = (
+            {"timeout": self.timeout} if self.timeout is not None else {}
+        )
+        try:
+            async for chunk in self._stream_rag_response(
+                url, rag_request, client_kwargs
+            ):
+                yield chunk
+        except (httpx.TimeoutException, httpx.ConnectError) as e:
+            raise _transport_error(e) from e
+
+    async def _send_stream(
+        self, client: httpx.AsyncClient, endpoint: str, url: str, **kwargs
+    ) -> httpx.Response:
+        # Streaming counterpart of `_send`, the caller closes the response
+        if self.circuit_breaker:
+            self.circuit_breaker.before_request()
+        try:
+            if self.rate_limiter:
+                # Blocks the event loop, which only drives this stream
+                self.rate_limiter.acquire()
+            status_code = None
+            t0 = time.monotonic()
+            try:
+                response = await client.send(
+                    client.build_request("POST", url, **kwargs), stream=True
+                )
+                status_code = response.status_code
+            finally:
+                # Timed to the response headers, not the end of the stream
+                if self.on_request:
+                    self.on_request(
+                        "POST", endpoint, status_code, time.monotonic() - t0
+                    )
+        except BaseException:
+            if self.circuit_breaker:
+                self.circuit_breaker.record_failure()
+            raise
+        if self.circuit_breaker:
+            if response.status_code >= 500:
+                self.circuit_breaker.record_failure()
+            else:
+                self.circuit_breaker.record_success()
+        return response
+
+    async def _stream_rag_response(
+        self, url: str, rag_request: R2RRAGRequest, client_kwargs: dict
+    ) -> AsyncGenerator[str, None]:
+        self.last_request_id = self.request_id_factory()
+        content = self.json_dumps(rag_request.model_dump(mode="json"))
+        headers = {
+            "Content-Type": "application/json",
+            "User-Agent": self.user_agent,
+            "X-Request-ID": self.last_request_id,
+        }
+        async with httpx.AsyncClient(**client_kwargs) as client:
+            for attempt in range(self.max_rate_limit_retries + 1):
+                response = await self._send_stream(
+                    client, "rag", url, content=content, headers=headers
+                )
+                self.last_rate_limit = _rate_limit_headers(response)
+                if (
+                    response.status_code != 429
+                    or attempt == self.max_rate_limit_retries
+                ):
+                    break
+                await response.aclose()
+                retry_after = _retry_after(response)
+                await asyncio.sleep(
+                    retry_after
+                    if retry_after is not None
+                    else _backoff_delay(attempt)
+                )
+            try:
+                if response.status_code >= 400:
+                    await response.aread()
+                handle_request_error(response, self.json_loads)
                 async for chunk in response.aiter_text():
                     yield chunk
+            finally:
+                await response.aclose()
 
     def _stream_rag_sync(
         self, rag_request: R2RRAGRequest
@@ -316,29 +1617,146 @@ class R2RClient:
         finally:
             loop.close()
 
+    def evaluate(self, query: str, context: str, completion: str) -> dict:
+        request = R2REvalRequest(
+            query=query, context=context, completion=completion
+        )
+        return self._make_request(
+            "POST", "evaluate", json=request.model_dump(mode="json")
+        )
+
+    def evaluate_dataset(
+        self,
+        dataset: list[dict[str, Any]],
+        max_workers: int = 4,
+        grade: bool = False,
+        **rag_kwargs,
+    ) -> dict:
+        # Each item needs a `query`; any other keys are copied to its result
+        def run_item(item: dict[str, Any]) -> dict[str, Any]:
+            result: dict[str, Any] = dict(item)
+            t0 = time.monotonic()
+            try:
+                response = unwrap_results(
+                    self.rag(item["query"], **rag_kwargs)
+                )
+                result["latency"] = time.monotonic() - t0
+                completion = response["completion"]
+                message = completion["choices"][0]["message"]
+                usage = completion.get("usage") or {}
+                result["answer"] = message["content"]
+                result["total_tokens"] = usage.get("total_tokens")
+                if grade:
+                    result["evaluation"] = unwrap_results(
+                        self.evaluate(
+                            query=item["query"],
+                            context=self.json_dumps(
+                                response["search_results"]
+                            ),
+                            completion=result["answer"],
+                        )
+                    )
+            except (
+                requests.RequestException,
+                R2RHTTPError,
+                R2RTransportError,
+                R2RCircuitOpenError,
+            ) as e:
+                result["latency"] = time.monotonic() - t0
+                result["error"] = e
+            return result
+
+        with ThreadPoolExecutor(max_workers=max_workers) as executor:
+            results = list(executor.map(run_item, dataset))
+
+        succeeded = [result for result in results if "error" not in result]
+        latencies = sorted(result["latency"] for result in succeeded)
+        tokens = [
+            result["total_tokens"]
+            for result in succeeded
+            if result["total_tokens"] is not None
+        ]
+        return {
+            "results": results,
+            "metrics": {
+                "count": len(results),
+                "errors": len(results) - len(succeeded),
+                "mean_latency": (
+                    sum(latencies) / len(latencies) if latencies else None
+                ),
+                "max_latency": latencies[-1] if latencies else None,
+                "total_tokens": sum(tokens),
+            },
+        }
+
     def delete(
         self, keys: list[str], values: list[Union[bool, int, str]]
     ) -> dict:
+        # Deletes can't be undone, so refuse filters that could match too much
+        if not keys or len(keys) != len(values):
+            raise ValueError(
+                "delete needs at least one key and exactly one value per key."
+            )
         request = R2RDeleteRequest(keys=keys, values=values)
         return self._make_request(
-            "DELETE", "delete", json=json.loads(request.json())
+            "DELETE", "delete", json=request.model_dump(mode="json")
         )
 
+    # Read endpoints that take a body are called with POST, since proxies may
+    # drop GET bodies and large filters don't fit in a query string.
     def logs(self, log_type_filter: Optional[str] = None) -> dict:
         request = R2RLogsRequest(log_type_filter=log_type_filter)
         return self._make_request(
-            "GET", "logs", json=json.loads(request.json())
+            "POST", "logs", json=request.model_dump(mode="json")
         )
 
+    def follow_logs(
+        self,
+        log_type_filter: Optional[str] = None,
+        interval: float = 2.0,
+        max_polls: Optional[int] = None,
+    ) -> Iterator[dict]:
+        # Polls `logs` and yields each run's entries only once, oldest first
+        seen: dict[str, int] = {}
+        polls = 0
+        while max_polls is None or polls < max_polls:
+            if polls:
+                time.sleep(interval)
+            polls += 1
+            runs = unwrap_results(self.logs(log_type_filter))
+            for run in reversed(runs):
+                count = seen.get(run["run_id"], 0)
+                if len(run["entries"]) > count:
+                    yield {**run, "entries": run["entries"][count:]}
+            # Forget runs that have dropped out of the server's window
+            seen = {run["run_id"]: len(run["entries"]) for run in runs}
+
     def app_settings(self) -> dict:
         return self._make_request("GET", "app_settings")
 
+    def get_app_settings(self) -> AppSettings:
+        return AppSettings.from_settings(
+            unwrap_results(self.app_settings()), self.json_loads
+        )
+
+    def get_prompt(self, name: str) -> dict:
+        prompts = unwrap_results(self.app_settings())["prompts"]
+        if name not in prompts:
+            raise ValueError(
+                f"Prompt '{name}' not found, expected one of {sorted(prompts)}."
+            )
+        return prompts[name]
+
+    def get_default_rag_prompt(self) -> str:
+        # The template `rag` uses unless `task_prompt_override` is given
+        return self.get_prompt("default_rag")["template"]
+
     def analytics(self, filter_criteria: dict, analysis_types: dict) -> dict:
         request = R2RAnalyticsRequest(
             filter_criteria=filter_criteria, analysis_types=analysis_types
         )
         return self._make_request(
-            "GET", "analytics", json=json.loads(request.json())
+            "POST", "analytics", json=request.model_dump(mode="json")
         )
 
     def users_overview(
@@ -346,13 +1764,18 @@ class R2RClient:
     ) -> dict:
         request = R2RUsersOverviewRequest(user_ids=user_ids)
         return self._make_request(
-            "GET", "users_overview", json=json.loads(request.json())
+            "POST", "users_overview", json=request.model_dump(mode="json")
         )
 
     def documents_overview(
         self,
         document_ids: Optional[list[str]] = None,
         user_ids: Optional[list[str]] = None,
+        metadata_filters: Optional[dict[str, Any]] = None,
+        offset: int = 0,
+        limit: Optional[int] = None,
+        fields: Optional[list[str]] = None,
+        cursor: Optional[str] = None,
     ) -> dict:
         request = R2RDocumentsOverviewRequest(
             document_ids=(
@@ -363,17 +1786,83 @@ class R2RClient:
             user_ids=(
                 [uuid.UUID(uid) for uid in user_ids] if user_ids else None
             ),
+            metadata_filters=metadata_filters,
+            offset=offset,
+            limit=limit,
+            fields=fields,
+            cursor=cursor,
         )
         return self._make_request(
-            "GET", "documents_overview", json=json.loads(request.json())
+            "POST", "documents_overview", json=request.model_dump(mode="json")
         )
 
-    def document_chunks(self, document_id: str) -> dict:
-        request = R2RDocumentChunksRequest(document_id=document_id)
+    def iter_documents_overview(
+        self,
+        document_ids: Optional[list[str]] = None,
+        user_ids: Optional[list[str]] = None,
+        metadata_filters: Optional[dict[str, Any]] = None,
+        page_size: int = 100,
+    ) -> Iterator[dict]:
+        # Pages with a cursor rather than an offset, so documents ingested or
+        # deleted mid-iteration don't cause skipped or repeated rows
+        cursor = None
+        while True:
+            page = unwrap_results(
+                self.documents_overview(
+                    document_ids,
+                    user_ids,
+                    metadata_filters=metadata_filters,
+                    limit=page_size,
+                    cursor=cursor,
+                )
+            )
+            yield from page
+            if len(page) < page_size:
+                return
+            last = page[-1]
+            cursor = f"{last['created_at']}|{last['document_id']}"
+
+    def get_document(self, document_id: str) -> dict:
+        response = self.documents_overview(document_ids=[str(document_id)])
+        results = unwrap_results(response) or []
+        if not results:
+            raise R2RHTTPError(
+                status_code=404,
+                error_type="NotFoundError",
+                message=f"Document {document_id} not found.",
+            )
+        return {"results": results[0]}
+
+    def document_chunks(
+        self,
+        document_id: str,
+        offset: int = 0,
+        limit: Optional[int] = None,
+    ) -> dict:
+        request = R2RDocumentChunksRequest(
+            document_id=document_id, offset=offset, limit=limit
+        )
         return self._make_request(
-            "GET", "document_chunks", json=json.loads(request.json())
+            "POST", "document_chunks", json=request.model_dump(mode="json")
         )
 
+    def iter_document_chunks(
+        self, document_id: str, page_size: int = 100
+    ) -> Iterator[DocumentChunk]:
+        # Pages through the chunks in order, so large documents stay cheap
+        offset = 0
+        while True:
+            page = unwrap_results(
+                self.document_chunks(
+                    document_id, offset=offset, limit=page_size
+                )
+            )
+            for chunk in page:
+                yield DocumentChunk.from_metadata(chunk)
+            if len(page) < page_size:
+                return
+            offset += page_size
+
 
 if __name__ == "__main__":
     client = R2RClient(base_url="http://localhost:8000")
@@ -1,5 +1,5 @@
 import uuid
-from typing import Optional, Union
+from typing import Any, Optional, Union
 
 from pydantic import BaseModel
 
@@ -56,6 +56,8 @@ class R2RRAGRequest(BaseModel):
     vector_search_settings: Optional[VectorSearchSettings] = None
     kg_search_settings: Optional[KGSearchSettings] = None
     rag_generation_config: Optional[GenerationConfig] = None
+    task_prompt_override: Optional[str] = None
+    dry_run: bool = False
 
 
 class R2REvalRequest(BaseModel):
@@ -81,10 +83,18 @@ class R2RUsersOverviewRequest(BaseModel):
 class R2RDocumentsOverviewRequest(BaseModel):
     document_ids: Optional[list[uuid.UUID]]
     user_ids: Optional[list[uuid.UUID]]
+    metadata_filters: Optional[dict[str, Any]] = None
+    offset: int = 0
+    limit: Optional[int] = None
+    fields: Optional[list[str]] = None
+    # `<created_at>|<document_id>` of the last document already seen
+    cursor: Optional[str] = None
 
 
 class R2RDocumentChunksRequest(BaseModel):
     document_id: uuid.UUID
+    offset: int = 0
+    limit: Optional[int] = None
 
 
 class R2RLogsRequest(BaseModel):
@@ -5,7 +5,7 @@ from fastapi import APIRouter, HTTPException
 from fastapi.responses import StreamingResponse
 
 from r2r.base import manage_run
-from r2r.main.abstractions import R2RException
+from r2r.main.abstractions import R2RException, is_rate_limit_error
 
 logger = logging.getLogger(__name__)
 
@@ -33,6 +33,11 @@ class BaseRouter:
                         detail={
                             "message": re.message,
                             "error_type": type(re).__name__,
+                            **(
+                                {"detail": re.detail}
+                                if re.detail is not None
+                                else {}
+                            ),
                         },
                     )
                 except Exception as e:
@@ -61,7 +66,7 @@ class BaseRouter:
                     )
                     logger.error(f"{func.__name__}() - \n\n{str(e)})")
                     raise HTTPException(
-                        status_code=500,
+                        status_code=429 if is_rate_limit_error(e) else 500,
                         detail={
                             "message": f"An error occurred during {func.__name__}",
                             "error": str(e),
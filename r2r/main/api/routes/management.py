@@ -66,14 +66,24 @@ class ManagementRouter(BaseRouter):
             request: R2RDocumentsOverviewRequest,
         ):
             return await self.engine.adocuments_overview(
-                document_ids=request.document_ids, user_ids=request.user_ids
+                document_ids=request.document_ids,
+                user_ids=request.user_ids,
+                metadata_filters=request.metadata_filters,
+                offset=request.offset,
+                limit=request.limit,
+                fields=request.fields,
+                cursor=request.cursor,
             )
 
         @self.router.post("/document_chunks")
         @self.router.get("/document_chunks")
         @self.base_endpoint
         async def get_document_chunks_app(request: R2RDocumentChunksRequest):
-            return await self.engine.adocument_chunks(request.document_id)
+            return await self.engine.adocument_chunks(
+                request.document_id,
+                offset=request.offset,
+                limit=request.limit,
+            )
 
         @self.router.get("/app_settings")
         @self.base_endpoint
@@ -36,6 +36,8 @@ class RetrievalRouter(BaseRouter):
                 or KGSearchSettings(),
                 rag_generation_config=request.rag_generation_config
                 or GenerationConfig(model="gpt-4o"),
+                task_prompt_override=request.task_prompt_override,
+                dry_run=request.dry_run,
             )
 
             if (
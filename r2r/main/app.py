@@ -1,13 +1,19 @@
-from fastapi import FastAPI
+import logging
+import uuid
+
+from fastapi import FastAPI, Request
 
 from .engine import R2REngine
 
+logger = logging.getLogger(__name__)
+
 
 class R2RApp:
     def __init__(self, engine: R2REngine):
         self.engine = engine
         self._setup_routes()
         self._apply_cors()
+        self._apply_request_ids()
 
     async def openapi_spec(self, *args, **kwargs):
         from fastapi.openapi.utils import get_openapi
@@ -45,6 +51,22 @@ class R2RApp:
             allow_headers=["*"],
         )
 
+    def _apply_request_ids(self):
+        @self.app.middleware("http")
+        async def request_id_middleware(request: Request, call_next):
+            # Echo the caller's ID (or a new one) so failures can be matched
+            # with the server logs
+            request_id = request.headers.get("X-Request-ID") or str(
+                uuid.uuid4()
+            )
+            response = await call_next(request)
+            response.headers["X-Request-ID"] = request_id
+            if response.status_code >= 400:
+                logger.warning(
+                    f"{request.method} {request.url.path} failed with {response.status_code} (request ID {request_id})"
+                )
+            return response
+
     def serve(self, host: str = "0.0.0.0", port: int = 8000):
         import uvicorn
 
@@ -5,6 +5,7 @@ from enum import Enum
 from typing import Any
 
 from ...base.abstractions.document import DocumentType
+from ...base.abstractions.llm import GenerationConfig
 from ...base.logging.kv_logger import LoggingConfig
 from ...base.providers.embedding_provider import EmbeddingConfig
 from ...base.providers.eval_provider import EvalConfig
@@ -83,6 +84,8 @@ class R2RConfig:
         self.logging = LoggingConfig.create(**self.logging)
         self.prompt = PromptConfig.create(**self.prompt)
         self.vector_database = VectorDBConfig.create(**self.vector_database)
+        self.generation_presets = default_config.get("generation_presets", {})
+        self.register_generation_presets(self.generation_presets)
 
     def _validate_config_section(
         self, config_data: dict[str, Any], section: str, keys: list
@@ -92,6 +95,11 @@ class R2RConfig:
         if not all(key in config_data[section] for key in keys):
             raise ValueError(f"Missing required keys in '{section}' config")
 
+    @staticmethod
+    def register_generation_presets(presets: dict[str, dict[str, Any]]):
+        for name, preset in presets.items():
+            GenerationConfig.register_preset(name, GenerationConfig(**preset))
+
     @classmethod
     def from_json(cls, config_path: str = None) -> "R2RConfig":
         if config_path is None:
@@ -17,7 +17,7 @@ from r2r.base import (
     increment_version,
     to_async_generator,
 )
-from r2r.main.abstractions import R2RException
+from r2r.main.abstractions import R2RException, is_rate_limit_error
 from r2r.pipes.ingestion.parsing_pipe import DocumentProcessingError
 from r2r.telemetry.telemetry_decorator import telemetry_event
 
@@ -28,6 +28,58 @@ from .base import Service
 
 logger = logging.getLogger(__name__)
 MB_CONVERSION_FACTOR = 1024 * 1024
+# Picks a parser from the part Content-Type when the file extension is
+# missing or unknown, since clients often send generic types
+CONTENT_TYPE_TO_EXTENSION = {
+    "application/json": "json",
+    "application/pdf": "pdf",
+    "application/vnd.openxmlformats-officedocument.presentationml.presentation": "pptx",
+    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet": "xlsx",
+    "application/vnd.openxmlformats-officedocument.wordprocessingml.document": "docx",
+    "audio/mpeg": "mp3",
+    "image/gif": "gif",
+    "image/jpeg": "jpeg",
+    "image/png": "png",
+    "image/svg+xml": "svg",
+    "text/csv": "csv",
+    "text/html": "html",
+    "text/markdown": "md",
+    "text/plain": "txt",
+    "video/mp4": "mp4",
+}
+
+
+def _file_extension(filename: str, content_type: Optional[str]) -> str:
+    extension = filename.split(".")[-1].lower() if "." in filename else ""
+    if extension.upper() in DocumentType.__members__:
+        return extension
+    return CONTENT_TYPE_TO_EXTENSION.get(
+        (content_type or "").split(";")[0].strip().lower(),
+        extension or filename.lower(),
+    )
+
+
+def _raise_for_rate_limits(
+    results: dict, failed_ids: list, report: dict
+) -> None:
+    # A 429 lets clients back off and retry, documents that were ingested
+    # are skipped as existing on the retry
+    rate_limited = [
+        str(document_id)
+        for document_id in failed_ids
+        if is_rate_limit_error(results[document_id].cause)
+    ]
+    if rate_limited:
+        raise R2RException(
+            status_code=429,
+            message=f"Rate limited by the embedding provider while ingesting {rate_limited}, retry later.",
+            detail={
+                **report,
+                "failed_document_ids": [
+                    str(document_id) for document_id in failed_ids
+                ],
+            },
+        )
 
 
 class IngestionService(Service):
@@ -167,12 +219,13 @@ class IngestionService(Service):
             document_info
             for document_info in document_infos
             if document_info.document_id not in skipped_ids
+            and document_info.document_id not in failed_ids
         ]
         if len(documents_to_upsert) > 0:
             self.providers.vector_db.upsert_documents_overview(
                 documents_to_upsert
             )
-        return {
+        report = {
             "processed_documents": [
                 f"Document '{processed_documents[document_id]}' processed successfully."
                 for document_id in processed_documents.keys()
@@ -187,6 +240,8 @@ class IngestionService(Service):
                 for _, title in skipped_documents
             ],
         }
+        _raise_for_rate_limits(results, failed_ids, report)
+        return report
 
     @telemetry_event("UpdateDocuments")
     async def update_documents(
@@ -321,7 +376,9 @@ class IngestionService(Service):
                         status_code=400, message="File name not provided."
                     )
 
-                file_extension = file.filename.split(".")[-1].lower()
+                file_extension = _file_extension(
+                    file.filename, file.content_type
+                )
                 if file_extension.upper() not in DocumentType.__members__:
                     logger.error(
                         f"'{file_extension}' is not a valid DocumentType"
@@ -439,8 +496,7 @@ class IngestionService(Service):
                 self.providers.vector_db.upsert_documents_overview(
                     documents_to_upsert
                 )
-
-            return {
+            report = {
                 "processed_documents": [
                     f"File '{processed_documents[document_id]}' processed successfully."
                     for document_id in processed_documents.keys()
@@ -455,6 +511,8 @@ class IngestionService(Service):
                     for _, filename in skipped_documents
                 ],
             }
+            _raise_for_rate_limits(results, failed_ids, report)
+            return report
 
         except Exception as e:
             raise e
@@ -544,6 +602,8 @@ class IngestionService(Service):
             )
             document_ids = ",".join([str(doc_id) for doc_id in document_ids])
             return f"Document(s) with IDs {document_ids} updated successfully."
+        except R2RException:
+            raise
         except Exception as e:
             logger.error(f"update_files(files={files}) - \n\n{str(e)})")
             raise R2RException(status_code=500, message=str(e)) from e
@@ -1,9 +1,11 @@
 import logging
 import uuid
+from datetime import datetime
 from typing import Any, Optional, Union
 
 from r2r.base import (
     AnalysisTypes,
+    DocumentInfo,
     FilterCriteria,
     KVLoggingSingleton,
     LogProcessor,
@@ -222,26 +224,68 @@ class ManagementService(Service):
         self,
         document_ids: Optional[list[uuid.UUID]] = None,
         user_ids: Optional[list[uuid.UUID]] = None,
+        metadata_filters: Optional[dict[str, Any]] = None,
+        offset: int = 0,
+        limit: Optional[int] = None,
+        fields: Optional[list[str]] = None,
+        cursor: Optional[str] = None,
         *args: Any,
         **kwargs: Any,
     ):
-        return self.providers.vector_db.get_documents_overview(
+        self._validate_overview_fields(fields)
+        after = None
+        if cursor:
+            # Keyset pagination stays stable while documents are ingested
+            created_at, _, document_id = cursor.rpartition("|")
+            try:
+                # Python < 3.11 doesn't parse the `Z` suffix JSON uses for UTC
+                datetime.fromisoformat(created_at.replace("Z", "+00:00"))
+                after = (created_at, str(uuid.UUID(document_id)))
+            except ValueError:
+                after = None
+            if not (after and created_at):
+                raise R2RException(
+                    status_code=400,
+                    message=f"Invalid cursor '{cursor}', expected '<created_at>|<document_id>'.",
+                )
+        documents = self.providers.vector_db.get_documents_overview(
             filter_document_ids=(
                 [str(ele) for ele in document_ids] if document_ids else None
             ),
             filter_user_ids=(
                 [str(ele) for ele in user_ids] if user_ids else None
             ),
+            filter_metadata=metadata_filters,
+            offset=offset,
+            limit=limit,
+            after=after,
         )
+        if not fields:
+            return documents
+        # Only return the requested columns to keep large listings small
+        return [document.dict(include=set(fields)) for document in documents]
+
+    @staticmethod
+    def _validate_overview_fields(fields: Optional[list[str]]) -> None:
+        unknown = sorted(set(fields or []) - set(DocumentInfo.model_fields))
+        if unknown:
+            raise R2RException(
+                status_code=400,
+                message=f"Unknown document field(s) {unknown}, expected any of {sorted(DocumentInfo.model_fields)}.",
+            )
 
     @telemetry_event("DocumentChunks")
     async def document_chunks(
         self,
         document_id: uuid.UUID,
+        offset: int = 0,
+        limit: Optional[int] = None,
         *args,
         **kwargs,
     ):
-        return self.providers.vector_db.get_document_chunks(str(document_id))
+        return self.providers.vector_db.get_document_chunks(
+            str(document_id), offset=offset, limit=limit
+        )
 
     @telemetry_event("UsersOverview")
     async def users_overview(
@@ -11,7 +11,7 @@ from r2r.base import (
 )
 from r2r.base.abstractions.llm import GenerationConfig
 from r2r.base.abstractions.search import KGSearchSettings, VectorSearchSettings
-from r2r.main.abstractions import R2RException
+from r2r.main.abstractions import R2RException, is_rate_limit_error
 from r2r.pipes import EvalPipe
 from r2r.telemetry.telemetry_decorator import telemetry_event
 
@@ -89,7 +89,41 @@ class RetrievalService(Service):
                 is_info_log=False,
             )
 
-            return results.dict()
+            return self._trim_search_results(
+                results.dict(), vector_search_settings
+            )
+
+    @staticmethod
+    def _trim_search_results(
+        results: dict, vector_search_settings: VectorSearchSettings
+    ) -> dict:
+        for result in results["vector_search_results"]:
+            if not vector_search_settings.include_metadata:
+                result["metadata"] = {}
+            elif not vector_search_settings.include_text:
+                result["metadata"] = {
+                    key: value
+                    for key, value in result["metadata"].items()
+                    if key != "text"
+                }
+        return results
+
+    @staticmethod
+    def _validate_task_prompt_override(template: str) -> None:
+        # The RAG pipes fill the override with `str.format`, so a bad
+        # template would otherwise fail mid-pipeline as a 500
+        try:
+            template.format(query="", context="")
+        except KeyError as e:
+            raise R2RException(
+                status_code=400,
+                message=f"Unknown placeholder '{{{e.args[0]}}}' in task_prompt_override, only '{{query}}' and '{{context}}' are available.",
+            )
+        except (IndexError, ValueError, AttributeError) as e:
+            raise R2RException(
+                status_code=400,
+                message=f"Invalid task_prompt_override ({e}), escape literal braces as '{{{{' and '}}}}'.",
+            )
 
     @telemetry_event("RAG")
     async def rag(
@@ -98,9 +132,19 @@ class RetrievalService(Service):
         rag_generation_config: GenerationConfig,
         vector_search_settings: VectorSearchSettings = VectorSearchSettings(),
         kg_search_settings: KGSearchSettings = KGSearchSettings(),
+        task_prompt_override: Optional[str] = None,
+        dry_run: bool = False,
         *args,
         **kwargs,
     ):
+        if task_prompt_override:
+            self._validate_task_prompt_override(task_prompt_override)
+        if dry_run and rag_generation_config.stream:
+            # Nothing is generated, so there is nothing to stream
+            raise R2RException(
+                status_code=400,
+                message="A dry run returns the prompt without generating, it can't be streamed.",
+            )
         async with manage_run(self.run_manager, "rag_app") as run_id:
             try:
                 t0 = time.time()
@@ -136,6 +180,7 @@ class RetrievalService(Service):
                                 vector_search_settings=vector_search_settings,
                                 kg_search_settings=kg_search_settings,
                                 rag_generation_config=rag_generation_config,
+                                task_prompt_override=task_prompt_override,
                             ):
                                 yield chunk
 
@@ -147,6 +192,8 @@ class RetrievalService(Service):
                     vector_search_settings=vector_search_settings,
                     kg_search_settings=kg_search_settings,
                     rag_generation_config=rag_generation_config,
+                    task_prompt_override=task_prompt_override,
+                    dry_run=dry_run,
                     *args,
                     **kwargs,
                 )
@@ -179,6 +226,11 @@ class RetrievalService(Service):
                         status_code=502,
                         message="Ollama server not reachable or returned an invalid response",
                     )
+                if is_rate_limit_error(e):
+                    raise R2RException(
+                        status_code=429,
+                        message="Rate limited by the model provider, retry later",
+                    )
                 raise R2RException(
                     status_code=500, message="Internal Server Error"
                 )
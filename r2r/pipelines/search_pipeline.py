@@ -43,7 +43,8 @@ class SearchPipeline(AsyncPipeline):
         *args: Any,
         **kwargs: Any,
     ):
-        self.state = state or AsyncState()
+        state = state or AsyncState()
+        self.state = state
         do_vector_search = (
             self._vector_search_pipeline is not None
             and vector_search_settings.use_vector_search
@@ -108,9 +109,21 @@ class SearchPipeline(AsyncPipeline):
         )
         kg_results = await kg_task if do_kg else None
 
+        query_embedding = None
+        if do_vector_search and vector_search_settings.include_embeddings:
+            # Reuse the vector the search pipe embedded the query with
+            for pipe in self._vector_search_pipeline.pipes:
+                pipe_state = state.data.get(pipe.config.name, {})
+                query_embeddings = pipe_state.get("output", {}).get(
+                    "query_embeddings", {}
+                )
+                if query_embeddings:
+                    query_embedding = next(iter(query_embeddings.values()))
+
         return AggregateSearchResult(
             vector_search_results=vector_search_results,
             kg_search_results=kg_results,
+            query_embedding=query_embedding,
         )
 
     def add_pipe(
@@ -220,6 +220,17 @@ class EmbeddingPipe(AsyncPipe):
                 await vector_entry_queue.put(vector_entry)
         except Exception as e:
             logger.error(f"Error processing batch: {e}")
-            raise e
+            # Nothing awaits this task, so report the failure downstream
+            # instead of raising it
+            for document_id in {
+                fragment.document_id for fragment in fragment_batch
+            }:
+                await vector_entry_queue.put(
+                    DocumentProcessingError(
+                        document_id=document_id,
+                        error_message=f"Embedding failed: {e}",
+                        cause=e,
+                    )
+                )
         finally:
             await vector_entry_queue.put(None)  # Signal completion
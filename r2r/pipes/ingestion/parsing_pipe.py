@@ -37,9 +37,11 @@ logger = logging.getLogger(__name__)
 
 
 class DocumentProcessingError(Exception):
-    def __init__(self, document_id, error_message):
+    def __init__(self, document_id, error_message, cause=None):
         self.document_id = document_id
         self.error_message = error_message
+        # The provider error behind it, e.g. to tell rate limits apart
+        self.cause = cause
         super().__init__(f"Error {error_message}")
 
 
@@ -84,8 +84,10 @@ class VectorStoragePipe(AsyncPipe):
         batch_tasks = []
         vector_batch = []
         document_counts = {}
+        failed_document_ids = set()
         async for msg in input.message:
             if isinstance(msg, DocumentProcessingError):
+                failed_document_ids.add(msg.document_id)
                 yield (msg.document_id, msg)
                 continue
 
@@ -120,6 +122,9 @@ class VectorStoragePipe(AsyncPipe):
         await asyncio.gather(*batch_tasks)
 
         for document_id, count in document_counts.items():
+            # A document whose other batches failed is reported as failed
+            if document_id in failed_document_ids:
+                continue
             yield (
                 document_id,
                 f"Processed {count} vectors for document {document_id}.",
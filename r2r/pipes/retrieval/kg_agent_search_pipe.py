@@ -4,6 +4,7 @@ from typing import Any, Optional
 
 from r2r.base import (
     AsyncState,
+    GenerationConfig,
     KGProvider,
     KGSearchSettings,
     KVLoggingSingleton,
@@ -71,7 +72,8 @@ class KGAgentSearchPipe(GeneratorPipe):
 
             result = self.llm_provider.get_completion(
                 messages=messages,
-                generation_config=kg_search_settings.agent_generation_config,
+                generation_config=kg_search_settings.agent_generation_config
+                or GenerationConfig(),
             )
 
             extraction = result.choices[0].message.content
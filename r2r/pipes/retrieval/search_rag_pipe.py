@@ -65,7 +65,17 @@ class SearchRAGPipe(GeneratorPipe):
             context += context_piece
             search_iteration += 1
 
-        messages = self._get_message_payload(sel_query, context)
+        messages = self._get_message_payload(
+            sel_query, context, kwargs.get("task_prompt_override", None)
+        )
+        if kwargs.get("dry_run", False):
+            # Stop before generation so the assembled prompt can be inspected
+            yield RAGCompletion(
+                completion=None,
+                search_results=search_results,
+                messages=messages,
+            )
+            return
 
         response = self.llm_provider.get_completion(
             messages=messages, generation_config=rag_generation_config
@@ -78,7 +88,13 @@ class SearchRAGPipe(GeneratorPipe):
             value=response.choices[0].message.content,
         )
 
-    def _get_message_payload(self, query: str, context: str) -> dict:
+    def _get_message_payload(
+        self,
+        query: str,
+        context: str,
+        task_prompt_override: Optional[str] = None,
+    ) -> dict:
+        inputs = {"query": query, "context": context}
         return [
             {
                 "role": "system",
@@ -88,12 +104,12 @@ class SearchRAGPipe(GeneratorPipe):
             },
             {
                 "role": "user",
-                "content": self.prompt_provider.get_prompt(
-                    self.config.task_prompt,
-                    inputs={
-                        "query": query,
-                        "context": context,
-                    },
+                "content": (
+                    task_prompt_override.format(**inputs)
+                    if task_prompt_override
+                    else self.prompt_provider.get_prompt(
+                        self.config.task_prompt, inputs=inputs
+                    )
                 ),
             },
         ]
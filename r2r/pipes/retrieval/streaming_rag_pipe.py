@@ -76,7 +76,9 @@ class StreamingSearchRAGPipe(SearchRAGPipe):
 
             yield f"</{self.SEARCH_STREAM_MARKER}>"
 
-            messages = self._get_message_payload(query, context)
+            messages = self._get_message_payload(
+                query, context, kwargs.get("task_prompt_override", None)
+            )
             yield f"<{self.COMPLETION_STREAM_MARKER}>"
             response = ""
             for chunk in self.llm_provider.get_completion_stream(
@@ -106,8 +108,12 @@ class StreamingSearchRAGPipe(SearchRAGPipe):
         yield end_marker
 
     def _get_message_payload(
-        self, query: str, context: str
+        self,
+        query: str,
+        context: str,
+        task_prompt_override: Optional[str] = None,
     ) -> list[dict[str, str]]:
+        inputs = {"query": query, "context": context}
         return [
             {
                 "role": "system",
@@ -117,9 +123,12 @@ class StreamingSearchRAGPipe(SearchRAGPipe):
             },
             {
                 "role": "user",
-                "content": self.prompt_provider.get_prompt(
-                    self.config.task_prompt,
-                    inputs={"query": query, "context": context},
+                "content": (
+                    task_prompt_override.format(**inputs)
+                    if task_prompt_override
+                    else self.prompt_provider.get_prompt(
+                        self.config.task_prompt, inputs=inputs
+                    )
                 ),
             },
         ]
@@ -42,6 +42,7 @@ class VectorSearchPipe(SearchPipe):
         message: str,
         run_id: uuid.UUID,
         vector_search_settings: VectorSearchSettings,
+        query_embeddings: Optional[dict[str, list[float]]] = None,
         *args: Any,
         **kwargs: Any,
     ) -> AsyncGenerator[VectorSearchResult, None]:
@@ -58,22 +59,28 @@ class VectorSearchPipe(SearchPipe):
         query_vector = self.embedding_provider.get_embedding(
             message,
         )
+        if query_embeddings is not None:
+            query_embeddings[message] = list(query_vector)
         search_results = (
             self.vector_db_provider.hybrid_search(
                 query_vector=query_vector,
                 query_text=message,
                 filters=search_filters,
                 limit=search_limit,
+                include_embeddings=vector_search_settings.include_embeddings,
             )
             if vector_search_settings.do_hybrid_search
             else self.vector_db_provider.search(
                 query_vector=query_vector,
                 filters=search_filters,
                 limit=search_limit,
+                include_embeddings=vector_search_settings.include_embeddings,
             )
         )
         reranked_results = self.embedding_provider.rerank(
-            query=message, results=search_results, limit=search_limit
+            query=message,
+            results=search_results,
+            limit=vector_search_settings.rerank_limit or search_limit,
         )
         for result in reranked_results:
             result.metadata["associatedQuery"] = message
@@ -96,12 +103,16 @@ class VectorSearchPipe(SearchPipe):
     ) -> AsyncGenerator[VectorSearchResult, None]:
         search_queries = []
         search_results = []
+        query_embeddings = (
+            {} if vector_search_settings.include_embeddings else None
+        )
         async for search_request in input.message:
             search_queries.append(search_request)
             async for result in self.search(
                 message=search_request,
                 run_id=run_id,
                 vector_search_settings=vector_search_settings,
+                query_embeddings=query_embeddings,
                 *args,
                 **kwargs,
             ):
@@ -118,6 +129,7 @@ class VectorSearchPipe(SearchPipe):
                 "output": {
                     "search_queries": search_queries,
                     "search_results": search_results,
+                    "query_embeddings": query_embeddings or {},
                 }
             },
         )
@@ -144,11 +144,11 @@ class SentenceTransformerEmbeddingProvider(EmbeddingProvider):
         for score in reranked_scores:
             corpus_id = score["corpus_id"]
             new_result = results[corpus_id]
-            new_result.score = float(score["score"])
+            new_result.rerank_score = float(score["score"])
             reranked_results.append(new_result)
 
-        # Sort the documents by the new scores in descending order
-        reranked_results.sort(key=lambda doc: doc.score, reverse=True)
+        # Sort the documents by the rerank scores in descending order
+        reranked_results.sort(key=lambda doc: doc.rerank_score, reverse=True)
         return reranked_results
 
     def tokenize_string(
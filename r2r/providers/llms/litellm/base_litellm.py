@@ -104,6 +104,12 @@ class LiteLLM(LLMProvider):
             # TODO - We need to cap this to avoid potential errors when exceed max allowable context
             "max_tokens": generation_config.max_tokens_to_sample,
         }
+        if generation_config.api_base:
+            args["api_base"] = generation_config.api_base
+        if generation_config.stop:
+            args["stop"] = generation_config.stop
+        if generation_config.logit_bias:
+            args["logit_bias"] = generation_config.logit_bias
         return args
 
     async def aget_completion(
@@ -89,7 +89,17 @@ class OpenAILLM(LLMProvider):
 
         args = {**args, **kwargs}
         # Create the chat completion
-        return self.client.chat.completions.create(**args)
+        return self._get_client(generation_config).chat.completions.create(
+            **args
+        )
+
+    def _get_client(self, generation_config: GenerationConfig):
+        """Get a client that targets the per-call `api_base`, if one is set."""
+        if generation_config.api_base:
+            return self.client.with_options(
+                base_url=generation_config.api_base
+            )
+        return self.client
 
     def _get_base_args(
         self,
@@ -105,6 +115,10 @@ class OpenAILLM(LLMProvider):
             # TODO - We need to cap this to avoid potential errors when exceed max allowable context
             "max_tokens": generation_config.max_tokens_to_sample,
         }
+        if generation_config.stop:
+            args["stop"] = generation_config.stop
+        if generation_config.logit_bias:
+            args["logit_bias"] = generation_config.logit_bias
 
         return args
 
@@ -141,4 +155,6 @@ class OpenAILLM(LLMProvider):
 
         args = {**args, **kwargs}
         # Create the chat completion
-        return await self.client.chat.completions.create(**args)
+        return await self._get_client(
+            generation_config
+        ).chat.completions.create(**args)
@@ -2,7 +2,7 @@ import json
 import logging
 import os
 import time
-from typing import Literal, Optional, Union
+from typing import Any, Literal, Optional, Union
 
 from sqlalchemy import exc, text
 
@@ -290,7 +290,7 @@ class PGVectorDB(VectorDBProvider):
             key: {"$eq": value} for key, value in filters.items()
         }
 
-        return [
+        results = [
             VectorSearchResult(id=ele[0], score=float(1 - ele[1]), metadata=ele[2])  # type: ignore
             for ele in self.collection.query(
                 data=query_vector,
@@ -301,6 +301,9 @@ class PGVectorDB(VectorDBProvider):
                 include_metadata=True,
             )
         ]
+        if kwargs.get("include_embeddings"):
+            self._attach_embeddings(results)
+        return results
 
     def hybrid_search(
         self,
@@ -347,10 +350,24 @@ class PGVectorDB(VectorDBProvider):
 
         with self.vx.Session() as session:
             result = session.execute(query, params).fetchall()
-        return [
+        results = [
             VectorSearchResult(id=row[0], score=1.0, metadata=row[-1])
             for row in result
         ]
+        if kwargs.get("include_embeddings"):
+            self._attach_embeddings(results)
+        return results
+
+    def _attach_embeddings(self, results: list[VectorSearchResult]) -> None:
+        # Vectors are only fetched on request, they dwarf the rest of a result
+        vectors = {
+            str(record[0]): [float(value) for value in record[1]]
+            for record in self.collection.fetch(
+                [str(result.id) for result in results]
+            )
+        }
+        for result in results:
+            result.embedding = vectors.get(str(result.id))
 
     def create_index(self, index_type, column_name, index_options):
         pass
@@ -469,6 +486,10 @@ class PGVectorDB(VectorDBProvider):
         self,
         filter_document_ids: Optional[list[str]] = None,
         filter_user_ids: Optional[list[str]] = None,
+        filter_metadata: Optional[dict[str, Any]] = None,
+        offset: int = 0,
+        limit: Optional[int] = None,
+        after: Optional[tuple[str, str]] = None,
     ):
         conditions = []
         params = {}
@@ -495,6 +516,15 @@ class PGVectorDB(VectorDBProvider):
                     for i, user_id in enumerate(filter_user_ids)
                 }
             )
+        if filter_metadata:
+            conditions.append("metadata @> CAST(:metadata AS JSONB)")
+            params["metadata"] = json.dumps(filter_metadata)
+        if after:
+            # Resume after the (created_at, document_id) of the last row seen
+            conditions.append(
+                "(created_at, document_id) > (CAST(:after_created_at AS TIMESTAMPTZ), CAST(:after_document_id AS UUID))"
+            )
+            params["after_created_at"], params["after_document_id"] = after
 
         query = f"""
             SELECT document_id, title, user_id, version, size_in_bytes, created_at, updated_at, metadata
@@ -502,6 +532,11 @@ class PGVectorDB(VectorDBProvider):
         """
         if conditions:
             query += " WHERE " + " AND ".join(conditions)
+        query += " ORDER BY created_at, document_id OFFSET :offset"
+        params["offset"] = offset
+        if limit is not None:
+            query += " LIMIT :limit"
+            params["limit"] = limit
 
         with self.vx.Session() as sess:
             results = sess.execute(text(query), params).fetchall()
@@ -519,25 +554,34 @@ class PGVectorDB(VectorDBProvider):
                 for row in results
             ]
 
-    def get_document_chunks(self, document_id: str) -> list[dict]:
+    def get_document_chunks(
+        self,
+        document_id: str,
+        offset: int = 0,
+        limit: Optional[int] = None,
+    ) -> list[dict]:
         if not self.collection:
             raise ValueError("Collection is not initialized.")
 
         table_name = self.collection.table.name
-        query = text(
-            f"""
-            SELECT metadata
+        query = f"""
+            SELECT id, metadata
             FROM vecs."{table_name}"
             WHERE metadata->>'document_id' = :document_id
             ORDER BY CAST(metadata->>'chunk_order' AS INTEGER)
+            OFFSET :offset
         """
-        )
-
-        params = {"document_id": document_id}
+        params = {"document_id": document_id, "offset": offset}
+        if limit is not None:
+            query += " LIMIT :limit"
+            params["limit"] = limit
 
         with self.vx.Session() as sess:
-            results = sess.execute(query, params).fetchall()
-            return [result[0] for result in results]
+            results = sess.execute(text(query), params).fetchall()
+            return [
+                {"fragment_id": str(result[0]), **result[1]}
+                for result in results
+            ]
 
     def get_users_overview(self, user_ids: Optional[list[str]] = None):
         user_ids_condition = ""
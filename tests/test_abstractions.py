@@ -3,8 +3,10 @@ import uuid
 import pytest
 
 from r2r import (
+    AggregateSearchResult,
     AsyncPipe,
     AsyncState,
+    GenerationConfig,
     Prompt,
     Vector,
     VectorEntry,
@@ -133,3 +135,41 @@ def test_vector_entry_serialization():
     assert isinstance(
         serializable["metadata"]["key"], str
     )  # Check UUID conversion to string
+
+
+@pytest.fixture
+def preset_registry(monkeypatch):
+    # Registering presets mutates a class-level dict shared by every test
+    monkeypatch.setattr(
+        GenerationConfig, "presets", dict(GenerationConfig.presets)
+    )
+    return GenerationConfig.presets
+
+
+def test_generation_config_presets(preset_registry):
+    GenerationConfig.register_preset(
+        "test_preset", GenerationConfig(model="test-model", temperature=0.5)
+    )
+    config = GenerationConfig.from_preset("test_preset", stream=True)
+    assert config.model == "test-model"
+    assert config.temperature == 0.5
+    assert config.stream is True
+    assert GenerationConfig.from_preset("test_preset").stream is False
+    with pytest.raises(ValueError):
+        GenerationConfig.from_preset("missing_preset")
+
+
+def test_search_result_dict_includes_embedding_only_when_set():
+    result = VectorSearchResult(
+        id=generate_id_from_label("1"), score=9.5, metadata={}
+    )
+    assert "embedding" not in result.dict()
+    result.embedding = [0.1, 0.2]
+    assert result.dict()["embedding"] == [0.1, 0.2]
+
+
+def test_aggregate_result_dict_includes_query_embedding_only_when_set():
+    aggregate = AggregateSearchResult(vector_search_results=[])
+    assert "query_embedding" not in aggregate.dict()
+    aggregate.query_embedding = [0.3, 0.4]
+    assert aggregate.dict()["query_embedding"] == [0.3, 0.4]
@@ -0,0 +1,604 @@
+import json
+
+import httpx
+import pytest
+import requests
+
+from r2r import (
+    AppSettings,
+    CircuitBreaker,
+    DocumentChunk,
+    GenerationConfig,
+    R2RCircuitOpenError,
+    R2RClient,
+    R2RHTTPError,
+    R2RValidationError,
+    RAGStreamParser,
+    RAGStreamResult,
+    SearchCache,
+    group_results_by_document,
+    merge_search_results,
+)
+from r2r.main.api.client import _upload_files, handle_request_error
+
+
+class FakeSession:
+    """Encodes each request like `requests` would and replies in turn."""
+
+    def __init__(self, status_codes):
+        self.status_codes = list(status_codes)
+        self.bodies = []
+
+    def request(self, method, url, timeout=None, **kwargs):
+        prepared = requests.Request(method, url, **kwargs).prepare()
+        self.bodies.append(prepared.body)
+        response = requests.Response()
+        response.status_code = self.status_codes.pop(0)
+        response.headers["Retry-After"] = "0"
+        response._content = b'{"results": "ok"}'
+        return response
+
+
+def _parse(chunks):
+    parser = RAGStreamParser()
+    events = []
+    for chunk in chunks:
+        events.extend(parser.feed(chunk))
+    sections = {}
+    for section, text in events:
+        sections[section] = sections.get(section, "") + text
+    return sections
+
+
+def test_rag_stream_parser_splits_sections():
+    sections = _parse(
+        ['<search>{"id": 1}</search>', "<completion>Hello", "</completion>"]
+    )
+    assert sections == {"search": '{"id": 1}', "completion": "Hello"}
+
+
+@pytest.mark.parametrize("chunk_size", [1, 2, 3, 7])
+def test_rag_stream_parser_handles_split_markers(chunk_size):
+    stream = "<search>[]</search><completion>a <b> c<d</completion>"
+    chunks = [
+        stream[i : i + chunk_size] for i in range(0, len(stream), chunk_size)
+    ]
+    sections = _parse(chunks)
+    assert sections == {"search": "[]", "completion": "a <b> c<d"}
+
+
+def test_rag_stream_result_assembles_final_result():
+    search_results = [{"id": "1", "score": 0.5}, {"id": "2", "score": 0.4}]
+    encoded = ",".join(
+        json.dumps(json.dumps(result)) for result in search_results
+    )
+    stream = f"<search>{encoded}</search><completion>The answer</completion>"
+    result = RAGStreamResult(iter([stream[:20], stream[20:45], stream[45:]]))
+
+    assert "".join(result) == "The answer"
+    assert result.done
+    assert result.completion == "The answer"
+    assert result.search_results == search_results
+
+
+def test_rag_stream_result_emits_sources_before_completion():
+    search_results = [{"id": "a", "metadata": {"text": "x"}}]
+    encoded = json.dumps(json.dumps(search_results[0]))
+    stream = f"<search>{encoded}</search><completion>Hi there</completion>"
+    chunks = [stream[i : i + 5] for i in range(0, len(stream), 5)]
+
+    events = list(RAGStreamResult(iter(chunks)).events())
+
+    assert events[0] == ("search_results", search_results)
+    assert [kind for kind, _ in events[1:]] == ["completion"] * (
+        len(events) - 1
+    )
+    assert "".join(text for _, text in events[1:]) == "Hi there"
+
+
+def test_group_results_by_document():
+    results = [
+        {"id": "a", "score": 0.5, "metadata": {"document_id": "doc_1"}},
+        {"id": "b", "score": 0.9, "metadata": {"document_id": "doc_2"}},
+        {"id": "c", "score": 0.7, "metadata": {"document_id": "doc_1"}},
+    ]
+    grouped = group_results_by_document(results)
+    assert [group["document_id"] for group in grouped] == ["doc_2", "doc_1"]
+    assert grouped[1]["score"] == 0.7
+    assert [chunk["id"] for chunk in grouped[1]["chunks"]] == ["a", "c"]
+
+
+def test_document_chunk_from_metadata():
+    chunk = DocumentChunk.from_metadata(
+        {
+            "fragment_id": "c5f2b1d0-0000-4000-8000-000000000001",
+            "document_id": "c5f2b1d0-0000-4000-8000-000000000002",
+            "chunk_order": 3,
+            "text": "Some text",
+            "title": "doc.txt",
+        }
+    )
+    assert chunk.chunk_order == 3
+    assert chunk.text == "Some text"
+    assert chunk.metadata == {"title": "doc.txt"}
+
+
+def test_merge_search_results_dedupes_and_sorts():
+    first = {
+        "results": {
+            "vector_search_results": [
+                {"id": "a", "score": 0.4, "metadata": {}},
+                {"id": "b", "score": 0.8, "metadata": {}},
+            ]
+        }
+    }
+    second = {
+        "vector_search_results": [
+            {"id": "a", "score": 0.9, "metadata": {}},
+            {"id": "c", "score": 0.1, "metadata": {}},
+        ]
+    }
+    merged = merge_search_results(first, second)
+    assert [r["id"] for r in merged["vector_search_results"]] == [
+        "a",
+        "b",
+        "c",
+    ]
+    assert merged["vector_search_results"][0]["score"] == 0.9
+
+
+def test_reranked_results_sort_by_rerank_score():
+    results = [
+        {
+            "id": "a",
+            "score": 0.9,
+            "rerank_score": 0.2,
+            "metadata": {"document_id": "doc-1"},
+        },
+        {
+            "id": "b",
+            "score": 0.5,
+            "rerank_score": 0.7,
+            "metadata": {"document_id": "doc-2"},
+        },
+    ]
+    merged = merge_search_results({"vector_search_results": results})
+    assert [r["id"] for r in merged["vector_search_results"]] == ["b", "a"]
+    documents = group_results_by_document(results)
+    assert [(d["document_id"], d["score"]) for d in documents] == [
+        ("doc-2", 0.7),
+        ("doc-1", 0.2),
+    ]
+
+
+def test_circuit_breaker_opens_and_probes():
+    breaker = CircuitBreaker(failure_threshold=2, reset_timeout=60)
+    breaker.record_failure()
+    breaker.before_request()
+    breaker.record_failure()
+    assert breaker.state == "open"
+    with pytest.raises(R2RCircuitOpenError):
+        breaker.before_request()
+
+    breaker.reset_timeout = 0
+    breaker.before_request()  # the single half-open probe
+    with pytest.raises(R2RCircuitOpenError):
+        breaker.before_request()
+    breaker.record_success()
+    assert breaker.state == "closed"
+
+
+def test_circuit_breaker_probe_ends_on_any_error():
+    class RedirectLoopSession:
+        def request(self, method, url, **kwargs):
+            raise requests.TooManyRedirects("redirect loop")
+
+    client = R2RClient(
+        "http://localhost:8000",
+        session=RedirectLoopSession(),
+        circuit_breaker=CircuitBreaker(failure_threshold=1, reset_timeout=0),
+    )
+    for _ in range(3):
+        # Each call is a half-open probe that must not leave the
+        # breaker stuck rejecting requests
+        with pytest.raises(requests.TooManyRedirects):
+            client.health()
+
+
+def test_validation_error_exposes_field_errors():
+    response = requests.Response()
+    response.status_code = 422
+    response._content = json.dumps(
+        {
+            "detail": [
+                {
+                    "loc": ["body", "query"],
+                    "msg": "field required",
+                    "type": "missing",
+                }
+            ]
+        }
+    ).encode()
+
+    with pytest.raises(R2RValidationError) as exc_info:
+        handle_request_error(response)
+
+    assert exc_info.value.status_code == 422
+    assert exc_info.value.errors[0].loc == ["body", "query"]
+    assert exc_info.value.message == "body.query: field required"
+
+
+def test_search_cache_expires_entries():
+    cache = SearchCache(ttl=60, maxsize=1)
+    cache.set("a", {"results": 1})
+    assert cache.get("a") == {"results": 1}
+    cache.set("b", {"results": 2})
+    assert cache.get("a") is None
+
+    expired = SearchCache(ttl=0)
+    expired.set("a", {"results": 1})
+    assert expired.get("a") is None
+
+
+def test_app_settings_from_settings():
+    config = {
+        "embedding": {
+            "provider": "openai",
+            "base_model": "text-embedding-3-small",
+            "base_dimension": 512,
+            "text_splitter": {"chunk_size": 512, "chunk_overlap": 20},
+        },
+        "completions": {"provider": "litellm"},
+        "ingestion": {"excluded_parsers": ["mp4"]},
+    }
+    settings = AppSettings.from_settings(
+        {"config": json.dumps(config), "prompts": {"default_rag": {}}}
+    )
+    assert settings.embedding_model == "text-embedding-3-small"
+    assert settings.embedding_dimension == 512
+    assert settings.chunk_size == 512
+    assert settings.excluded_parsers == ["mp4"]
+    assert settings.prompt_names == ["default_rag"]
+    assert settings.raw["config"] == config
+
+
+def test_upload_files_rejects_missing_paths_up_front(tmp_path):
+    path = tmp_path / "doc.txt"
+    path.write_text("document")
+    with pytest.raises(FileNotFoundError):
+        _upload_files([str(path), str(tmp_path / "missing.txt")], "files")
+
+
+def test_ingest_files_rejects_mismatched_content_types(tmp_path):
+    paths = []
+    for name in ["a.txt", "b.txt"]:
+        path = tmp_path / name
+        path.write_text("document")
+        paths.append(str(path))
+    with pytest.raises(ValueError):
+        _upload_files(paths, "files", content_types=["text/plain"])
+    client = R2RClient("http://localhost:8000", session=FakeSession([]))
+    with pytest.raises(ValueError):
+        client.ingest_files(
+            paths, validate_file_types=True, content_types=["text/plain"]
+        )
+
+
+def test_ingest_files_opens_one_file_at_a_time(tmp_path):
+    resource = pytest.importorskip("resource")
+    soft, hard = resource.getrlimit(resource.RLIMIT_NOFILE)
+    limit = 256 if hard == resource.RLIM_INFINITY else min(256, hard)
+    paths = []
+    for i in range(limit + 50):
+        path = tmp_path / f"doc_{i}.txt"
+        path.write_text(f"document {i}")
+        paths.append(str(path))
+    session = FakeSession([200])
+    client = R2RClient("http://localhost:8000", session=session)
+    resource.setrlimit(resource.RLIMIT_NOFILE, (limit, hard))
+    try:
+        client.ingest_files(paths)
+    finally:
+        resource.setrlimit(resource.RLIMIT_NOFILE, (soft, hard))
+    assert f"document {len(paths) - 1}".encode() in session.bodies[0]
+
+
+@pytest.mark.parametrize(
+    "base_url, prefix",
+    [
+        ("https://api.example.com/r2r", "/v1"),
+        ("https://api.example.com/r2r/", "v1/"),
+        ("https://api.example.com", "/r2r/v1/"),
+        ("https://api.example.com/r2r/v1", ""),
+    ],
+)
+def test_client_url_joins_prefix_without_double_slashes(base_url, prefix):
+    client = R2RClient(base_url, prefix=prefix)
+    assert client._url("search") == "https://api.example.com/r2r/v1/search"
+    assert client._url("/search") == "https://api.example.com/r2r/v1/search"
+
+
+def test_follow_logs_yields_only_new_entries(monkeypatch):
+    polls = [
+        [{"run_id": "a", "run_type": "search", "entries": [1]}],
+        [
+            {"run_id": "b", "run_type": "rag", "entries": [1]},
+            {"run_id": "a", "run_type": "search", "entries": [1, 2]},
+        ],
+        [{"run_id": "b", "run_type": "rag", "entries": [1]}],
+    ]
+    client = R2RClient("http://localhost:8000")
+    monkeypatch.setattr(
+        client, "logs", lambda log_type_filter=None: {"results": polls.pop(0)}
+    )
+    monkeypatch.setattr("time.sleep", lambda seconds: None)
+    runs = list(client.follow_logs(max_polls=3))
+    assert [(run["run_id"], run["entries"]) for run in runs] == [
+        ("a", [1]),
+        ("a", [2]),
+        ("b", [1]),
+    ]
+
+
+def test_iter_documents_overview_chains_pages_by_cursor(monkeypatch):
+    documents = [
+        {"document_id": f"id-{i}", "created_at": f"2024-06-0{i}T00:00:00Z"}
+        for i in range(1, 6)
+    ]
+    cursors = []
+
+    def documents_overview(
+        document_ids, user_ids, metadata_filters=None, limit=None, cursor=None
+    ):
+        cursors.append(cursor)
+        start = 0
+        if cursor:
+            start = next(
+                i + 1
+                for i, document in enumerate(documents)
+                if f"{document['created_at']}|{document['document_id']}"
+                == cursor
+            )
+        return {"results": documents[start : start + limit]}
+
+    client = R2RClient("http://localhost:8000")
+    monkeypatch.setattr(client, "documents_overview", documents_overview)
+    assert list(client.iter_documents_overview(page_size=2)) == documents
+    assert cursors == [
+        None,
+        "2024-06-02T00:00:00Z|id-2",
+        "2024-06-04T00:00:00Z|id-4",
+    ]
+
+
+def test_sync_prompts_updates_only_changed_prompts(tmp_path, monkeypatch):
+    (tmp_path / "a.jsonl").write_text(
+        json.dumps({"name": "same", "template": "{x}", "input_types": {}})
+        + "\n"
+        + json.dumps({"name": "changed", "template": "new", "input_types": {}})
+    )
+    (tmp_path / "b.json").write_text(
+        json.dumps([{"name": "unknown", "template": "t"}])
+    )
+    (tmp_path / "notes.txt").write_text("not a prompt")
+    existing = {
+        "same": {"template": "{x}", "input_types": {}},
+        "changed": {"template": "old", "input_types": {}},
+    }
+    updates = []
+    client = R2RClient("http://localhost:8000")
+    monkeypatch.setattr(
+        client, "app_settings", lambda: {"results": {"prompts": existing}}
+    )
+    monkeypatch.setattr(
+        client,
+        "update_prompt",
+        lambda name, template, input_types: updates.append(name),
+    )
+    summary = client.sync_prompts(str(tmp_path))
+    assert updates == ["changed"]
+    assert summary == {
+        "updated": ["changed"],
+        "unchanged": ["same"],
+        "missing": ["unknown"],
+        "skipped": ["notes.txt"],
+    }
+
+
+def test_ingest_urls_isolates_failures_per_url(monkeypatch):
+    def fake_get(url, **kwargs):
+        if url == "http://example.com/down":
+            raise requests.ConnectionError("connection refused")
+        download = requests.Response()
+        download.status_code = 200
+        # `latest` redirects to a real file name
+        download.url = (
+            "http://example.com/files/report.pdf"
+            if url == "http://example.com/latest"
+            else url
+        )
+        download.headers["Content-Type"] = "application/pdf"
+        download._content = b"%PDF"
+        return download
+
+    monkeypatch.setattr("requests.get", fake_get)
+    session = FakeSession([200, 500])
+    client = R2RClient(
+        "http://localhost:8000",
+        session=session,
+        circuit_breaker=CircuitBreaker(failure_threshold=1, reset_timeout=60),
+    )
+    results = client.ingest_urls(
+        [
+            "http://example.com/down",
+            "http://example.com/latest",
+            "http://example.com/",
+            "http://example.com/other.pdf",
+        ],
+        metadatas=[{}, {"title": "Report"}, {}, {}],
+    )
+
+    assert [result["url"] for result in results] == [
+        "http://example.com/down",
+        "http://example.com/latest",
+        "http://example.com/",
+        "http://example.com/other.pdf",
+    ]
+    assert isinstance(results[0]["error"], requests.ConnectionError)
+    assert results[1]["results"] == "ok"
+    assert isinstance(results[2]["error"], R2RHTTPError)
+    # The failed upload opened the circuit, which only fails the last URL
+    assert isinstance(results[3]["error"], R2RCircuitOpenError)
+
+    assert b'filename="report.pdf"' in session.bodies[0]
+    assert b'"source_url": "http://example.com/latest"' in session.bodies[0]
+    assert b'"title": "Report"' in session.bodies[0]
+    assert b'filename="index.html"' in session.bodies[1]
+
+
+def test_rate_limited_uploads_resend_file_bytes(tmp_path):
+    path = tmp_path / "doc.txt"
+    path.write_text("file contents")
+    session = FakeSession([429, 200, 429, 200])
+    client = R2RClient(
+        "http://localhost:8000", session=session, max_rate_limit_retries=1
+    )
+    client.ingest_files([str(path)])
+    client.ingest_text("text contents", "note")
+    assert len(session.bodies) == 4
+    assert all(b"file contents" in body for body in session.bodies[:2])
+    assert all(b"text contents" in body for body in session.bodies[2:])
+
+
+def test_rate_limited_unseekable_upload_is_not_retried():
+    class Stream:
+        def read(self, size=-1):
+            return b"streamed"
+
+        def seekable(self):
+            return False
+
+    session = FakeSession([429, 200])
+    client = R2RClient(
+        "http://localhost:8000", session=session, max_rate_limit_retries=1
+    )
+    with pytest.raises(R2RHTTPError) as exc_info:
+        client._ingest_file_object("a.txt", Stream(), "text/plain", {})
+    assert exc_info.value.status_code == 429
+    assert len(session.bodies) == 1
+
+
+def test_streaming_rag_goes_through_request_hooks(monkeypatch):
+    status_codes = [429, 200]
+
+    def handler(request):
+        status_code = status_codes.pop(0)
+        return httpx.Response(
+            status_code,
+            headers={"Retry-After": "0", "Content-Type": "text/plain"},
+            text="" if status_code == 429 else "streamed answer",
+        )
+
+    async_client = httpx.AsyncClient
+    monkeypatch.setattr(
+        httpx,
+        "AsyncClient",
+        lambda **kwargs: async_client(
+            transport=httpx.MockTransport(handler), **kwargs
+        ),
+    )
+    calls = []
+
+    def on_request(method, endpoint, status_code, latency):
+        calls.append((method, endpoint, status_code))
+
+    client = R2RClient(
+        "http://localhost:8000",
+        max_rate_limit_retries=1,
+        on_request=on_request,
+    )
+    chunks = client.rag(
+        "query", rag_generation_config=GenerationConfig(stream=True)
+    )
+    assert "".join(chunks) == "streamed answer"
+    assert calls == [("POST", "rag", 429), ("POST", "rag", 200)]
+
+
+def test_rag_dry_run_returns_prompt_without_completion():
+    messages = [
+        {"role": "system", "content": "You are a helpful assistant."},
+        {"role": "user", "content": "Context\nAnswer: What is R2R?"},
+    ]
+
+    class DryRunSession:
+        def request(self, method, url, data=None, **kwargs):
+            self.payload = json.loads(data)
+            response = requests.Response()
+            response.status_code = 200
+            response._content = json.dumps(
+                {
+                    "results": {
+                        "completion": None,
+                        "search_results": {"vector_search_results": []},
+                        "messages": messages,
+                    }
+                }
+            ).encode()
+            return response
+
+    session = DryRunSession()
+    client = R2RClient("http://localhost:8000", session=session)
+    results = client.rag("What is R2R?", dry_run=True)["results"]
+    assert session.payload["dry_run"] is True
+    assert results["completion"] is None
+    assert results["messages"] == messages
+
+
+def test_rag_dry_run_cannot_be_streamed():
+    client = R2RClient("http://localhost:8000", session=FakeSession([]))
+    with pytest.raises(ValueError):
+        client.rag(
+            "What is R2R?",
+            rag_generation_config=GenerationConfig(stream=True),
+            dry_run=True,
+        )
+
+
+def test_evaluate_dataset_records_failed_rows():
+    class RAGSession:
+        def request(self, method, url, data=None, **kwargs):
+            query = json.loads(data)["query"]
+            response = requests.Response()
+            if query == "broken":
+                response.status_code = 500
+                response._content = b'{"detail": "Internal Server Error"}'
+                return response
+            response.status_code = 200
+            response._content = json.dumps(
+                {
+                    "results": {
+                        "completion": {
+                            "choices": [
+                                {"message": {"content": f"About {query}"}}
+                            ],
+                            "usage": {"total_tokens": 12},
+                        },
+                        "search_results": {"vector_search_results": []},
+                    }
+                }
+            ).encode()
+            return response
+
+    client = R2RClient("http://localhost:8000", session=RAGSession())
+    report = client.evaluate_dataset(
+        [{"query": "R2R", "label": "ok"}, {"query": "broken"}]
+    )
+    ok, failed = report["results"]
+    assert ok["answer"] == "About R2R"
+    assert ok["label"] == "ok"
+    assert ok["total_tokens"] == 12
+    assert isinstance(failed["error"], R2RHTTPError)
+    assert failed["error"].status_code == 500
+    assert report["metrics"]["count"] == 2
+    assert report["metrics"]["errors"] == 1
+    assert report["metrics"]["total_tokens"] == 12
@@ -3,7 +3,7 @@ from unittest.mock import Mock, mock_open, patch
 
 import pytest
 
-from r2r import DocumentType, R2RConfig
+from r2r import DocumentType, GenerationConfig, R2RConfig
 
 
 @pytest.fixture
@@ -159,3 +159,21 @@ def test_r2r_config_missing_required_key():
     with patch("builtins.open", mock_open(read_data=json.dumps(invalid_data))):
         with pytest.raises(KeyError):
             R2RConfig.from_json("config.json")
+
+
+def test_generation_presets_are_registered_from_config(monkeypatch):
+    # Registering presets mutates a class-level dict shared by every test
+    monkeypatch.setattr(
+        GenerationConfig, "presets", dict(GenerationConfig.presets)
+    )
+    R2RConfig.register_generation_presets(
+        {"tuned": {"model": "tuned-model", "temperature": 0.3}}
+    )
+    assert GenerationConfig.from_preset("tuned").temperature == 0.3
+    # The built-in presets come from the default config.json
+    default_presets = R2RConfig.load_default_config()["generation_presets"]
+    assert set(default_presets) <= set(GenerationConfig.presets)
+    assert (
+        GenerationConfig.from_preset("fast").model
+        == default_presets["fast"]["model"]
+    )
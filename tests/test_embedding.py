@@ -123,6 +123,36 @@ def test_sentence_transformer_rerank(sentence_transformer_provider):
     assert reranked_results[1].metadata["text"] == "doc2"
 
 
+def test_sentence_transformer_rerank_keeps_vector_score(
+    sentence_transformer_provider,
+):
+    class ReversingEncoder:
+        def rank(self, query, texts, return_documents=False, top_k=10):
+            return [
+                {"corpus_id": i, "score": float(i)}
+                for i in range(len(texts))
+            ][:top_k]
+
+    sentence_transformer_provider.do_rerank = True
+    sentence_transformer_provider.rerank_encoder = ReversingEncoder()
+    results = [
+        VectorSearchResult(
+            id=generate_id_from_label(text),
+            score=score,
+            metadata={"text": text},
+        )
+        for text, score in [("doc1", 0.9), ("doc2", 0.8)]
+    ]
+    reranked_results = sentence_transformer_provider.rerank("query", results)
+    assert [result.metadata["text"] for result in reranked_results] == [
+        "doc2",
+        "doc1",
+    ]
+    assert [result.score for result in reranked_results] == [0.8, 0.9]
+    assert [result.rerank_score for result in reranked_results] == [1.0, 0.0]
+    assert reranked_results[0].dict()["rerank_score"] == 1.0
+
+
 def test_sentence_transformer_tokenize_string(sentence_transformer_provider):
     with pytest.raises(ValueError):
         sentence_transformer_provider.tokenize_string("test text")
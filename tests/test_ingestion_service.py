@@ -0,0 +1,109 @@
+import asyncio
+import uuid
+
+import httpx
+import openai
+import pytest
+
+from r2r import Fragment, FragmentType
+from r2r.main.abstractions import R2RException, is_rate_limit_error
+from r2r.main.services.ingestion_service import (
+    _file_extension,
+    _raise_for_rate_limits,
+)
+from r2r.pipes import EmbeddingPipe
+from r2r.pipes.ingestion.parsing_pipe import DocumentProcessingError
+
+
+@pytest.mark.parametrize(
+    "filename, content_type, expected",
+    [
+        ("notes.md", "text/plain", "md"),
+        ("table.csv", "application/json", "csv"),
+        ("page.HTML", None, "html"),
+        ("report", "application/pdf", "pdf"),
+        ("report.bin", "application/pdf; charset=binary", "pdf"),
+        ("report.bin", "application/octet-stream", "bin"),
+    ],
+)
+def test_file_extension_prefers_known_extension(
+    filename, content_type, expected
+):
+    assert _file_extension(filename, content_type) == expected
+
+
+class RateLimitError(Exception):
+    status_code = 429
+
+
+@pytest.mark.asyncio
+async def test_failed_embedding_batch_is_reported_per_document():
+    async def rate_limited_batch(fragment_batch):
+        raise RateLimitError("Too many requests")
+
+    # Only the batch handling is exercised, so skip the provider setup
+    pipe = EmbeddingPipe.__new__(EmbeddingPipe)
+    pipe._process_batch = rate_limited_batch
+    document_ids = [uuid.uuid4(), uuid.uuid4()]
+    fragments = [
+        Fragment(
+            id=uuid.uuid4(),
+            type=FragmentType.TEXT,
+            data="text",
+            metadata={},
+            document_id=document_id,
+            extraction_id=uuid.uuid4(),
+        )
+        for document_id in document_ids + document_ids[:1]
+    ]
+    queue = asyncio.Queue()
+    await pipe._process_and_enqueue_batch(fragments, queue)
+    errors = [queue.get_nowait() for _ in range(queue.qsize())]
+    assert errors[-1] is None
+    assert {error.document_id for error in errors[:-1]} == set(document_ids)
+    assert all(
+        isinstance(error.cause, RateLimitError) for error in errors[:-1]
+    )
+
+
+def test_rate_limited_documents_raise_429():
+    document_id = uuid.uuid4()
+    results = {
+        document_id: DocumentProcessingError(
+            document_id, "Embedding failed", cause=RateLimitError()
+        )
+    }
+    report = {"failed_documents": ["Document 'a': Embedding failed"]}
+    _raise_for_rate_limits({}, [], report)
+    with pytest.raises(R2RException) as exc_info:
+        _raise_for_rate_limits(results, [document_id], report)
+    assert exc_info.value.status_code == 429
+    assert exc_info.value.detail == {
+        **report,
+        "failed_document_ids": [str(document_id)],
+    }
+
+
+@pytest.mark.parametrize(
+    "error, expected",
+    [
+        (
+            openai.RateLimitError(
+                "Rate limit reached",
+                response=httpx.Response(
+                    429,
+                    request=httpx.Request(
+                        "POST", "https://api.openai.com/v1/embeddings"
+                    ),
+                ),
+                body=None,
+            ),
+            True,
+        ),
+        (RateLimitError("Too many requests"), True),
+        (ValueError("Error code: 429 in a document's text"), False),
+        (R2RException("Not found", status_code=404), False),
+    ],
+)
+def test_rate_limit_errors_are_matched_by_type_or_status(error, expected):
+    assert is_rate_limit_error(error) is expected
@@ -57,3 +57,29 @@ def test_get_completion_openai(request, llm_fixture):
     # assert isinstance(completion, LLMChatCompletion)
     assert completion.choices[0].message.role == "assistant"
     assert completion.choices[0].message.content.strip() == "True"
+
+
+def test_lite_llm_passes_api_base(lite_llm):
+    generation_config = GenerationConfig(
+        model="openai/local-model", api_base="http://localhost:8001/v1"
+    )
+    args = lite_llm._get_base_args(generation_config)
+    assert args["api_base"] == "http://localhost:8001/v1"
+    assert "api_base" not in lite_llm._get_base_args(GenerationConfig())
+
+
+def test_lite_llm_passes_stop_and_logit_bias(lite_llm):
+    generation_config = GenerationConfig(
+        stop=["\n\n"], logit_bias={"50256": -100.0}
+    )
+    args = lite_llm._get_base_args(generation_config)
+    assert args["stop"] == ["\n\n"]
+    assert args["logit_bias"] == {"50256": -100.0}
+    assert "stop" not in lite_llm._get_base_args(GenerationConfig())
+
+
+def test_generation_config_serializes_api_base():
+    generation_config = GenerationConfig(api_base="http://localhost:8001/v1")
+    assert generation_config.model_dump()["api_base"] == (
+        "http://localhost:8001/v1"
+    )
@@ -3,7 +3,13 @@ from typing import Any, AsyncGenerator
 
 import pytest
 
-from r2r import AsyncPipe, AsyncPipeline, PipeType
+from r2r import (
+    AsyncPipe,
+    AsyncPipeline,
+    PipeType,
+    SearchPipeline,
+    VectorSearchSettings,
+)
 
 
 class MultiplierPipe(AsyncPipe):
@@ -87,6 +93,34 @@ class FanInPipe(AsyncPipe):
         yield total_sum
 
 
+class QueryEmbeddingPipe(AsyncPipe):
+    def __init__(self, delay=0, name="vector_search_pipe"):
+        super().__init__(
+            type=PipeType.SEARCH,
+            config=self.PipeConfig(name=name),
+        )
+        self.delay = delay
+
+    async def _run_logic(
+        self,
+        input: AsyncGenerator[Any, None],
+        state,
+        run_id=None,
+        *args,
+        **kwargs,
+    ) -> AsyncGenerator[Any, None]:
+        query_embeddings = {}
+        async for query in input.message:
+            if self.delay > 0:
+                await asyncio.sleep(self.delay)
+            query_embeddings[query] = [float(len(query))]
+            yield query
+        await state.update(
+            self.config.name,
+            {"output": {"query_embeddings": query_embeddings}},
+        )
+
+
 @pytest.fixture
 def pipe_factory():
     def create_pipe(type, **kwargs):
@@ -289,3 +323,31 @@ async def test_fan_out_then_multiply(
     assert (
         result[0] == expected_result
     ), "Pipeline output did not match expected multipliers"
+
+
+@pytest.mark.asyncio
+async def test_concurrent_search_runs_keep_their_own_state():
+    pipeline = SearchPipeline()
+    pipeline.add_pipe(QueryEmbeddingPipe(delay=0.1), vector_search_pipe=True)
+    settings = VectorSearchSettings(include_embeddings=True)
+
+    async def input_generator(query):
+        yield query
+
+    short, long = await asyncio.gather(
+        pipeline.run(
+            input_generator("a"),
+            run_manager=pipeline.run_manager,
+            log_run_info=False,
+            vector_search_settings=settings,
+        ),
+        pipeline.run(
+            input_generator("a much longer query"),
+            run_manager=pipeline.run_manager,
+            log_run_info=False,
+            vector_search_settings=settings,
+        ),
+    )
+
+    assert short.query_embedding == [1.0]
+    assert long.query_embedding == [19.0]
@@ -0,0 +1,105 @@
+import uuid
+from types import SimpleNamespace
+
+import pytest
+
+from r2r import (
+    AggregateSearchResult,
+    GenerationConfig,
+    SearchRAGPipe,
+    VectorSearchResult,
+)
+from r2r.main.abstractions import R2RException
+from r2r.main.services.retrieval_service import RetrievalService
+from r2r.pipes.abstractions.generator_pipe import GeneratorPipe
+
+
+def test_task_prompt_override_accepts_known_placeholders():
+    RetrievalService._validate_task_prompt_override(
+        "Answer {query} using {context}, as JSON like {{\"a\": 1}}"
+    )
+
+
+@pytest.mark.parametrize(
+    "template, expected",
+    [
+        ("Answer {question}", "'{question}'"),
+        ("Answer {}", "escape literal braces"),
+        ('Return {"a": 1}', "'{\"a\"}'"),
+        ("Answer {query", "escape literal braces"),
+    ],
+)
+def test_task_prompt_override_rejects_bad_templates(template, expected):
+    with pytest.raises(R2RException) as exc_info:
+        RetrievalService._validate_task_prompt_override(template)
+    assert exc_info.value.status_code == 400
+    assert expected in exc_info.value.message
+
+
+@pytest.mark.asyncio
+async def test_dry_run_cannot_be_streamed():
+    # Rejected before any pipeline runs, so skip the service setup
+    service = RetrievalService.__new__(RetrievalService)
+    with pytest.raises(R2RException) as exc_info:
+        # Call past the telemetry decorator so tests don't report events
+        await RetrievalService.rag.__wrapped__(
+            service,
+            "What is R2R?",
+            GenerationConfig(model="gpt-4o", stream=True),
+            dry_run=True,
+        )
+    assert exc_info.value.status_code == 400
+
+
+class TemplatePromptProvider:
+    prompts = {
+        "default_system": "You are a helpful assistant.",
+        "default_rag": "{context}\nAnswer: {query}",
+    }
+
+    def get_prompt(self, name, inputs=None):
+        return self.prompts[name].format(**(inputs or {}))
+
+
+@pytest.mark.asyncio
+async def test_dry_run_returns_rendered_prompt_without_completion():
+    # Only prompt assembly is exercised, so skip the provider setup
+    pipe = SearchRAGPipe.__new__(SearchRAGPipe)
+    pipe.config = GeneratorPipe.Config(
+        name="default_rag_pipe", task_prompt="default_rag"
+    )
+    pipe.prompt_provider = TemplatePromptProvider()
+    pipe.llm_provider = None
+    search_results = AggregateSearchResult(
+        vector_search_results=[
+            VectorSearchResult(
+                id=uuid.uuid4(), score=0.9, metadata={"text": "R2R is RAG."}
+            )
+        ]
+    )
+
+    async def message():
+        yield "What is R2R?", search_results
+
+    completions = [
+        completion
+        async for completion in pipe._run_logic(
+            SimpleNamespace(message=message()),
+            state=None,
+            run_id=None,
+            rag_generation_config=GenerationConfig(model="gpt-4o"),
+            dry_run=True,
+        )
+    ]
+
+    assert len(completions) == 1
+    assert completions[0].completion is None
+    assert completions[0].search_results is search_results
+    assert completions[0].messages == [
+        {"role": "system", "content": "You are a helpful assistant."},
+        {
+            "role": "user",
+            "content": "Query:\nWhat is R2R?\n\nVector Search Results(1):\n"
+            "[1]: R2R is RAG.\n\n\nAnswer: What is R2R?",
+        },
+    ]
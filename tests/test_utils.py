@@ -0,0 +1,56 @@
+import pytest
+
+from r2r import extract_snippet
+
+TAGS = ("<b>", "</b>")
+
+
+def test_extract_snippet_without_match_returns_text_start():
+    assert (
+        extract_snippet("alpha beta gamma delta", "zeta", window=10)
+        == "alpha beta"
+    )
+
+
+@pytest.mark.parametrize(
+    "text, expected",
+    [
+        (
+            "Retrieval " + "filler " * 10,
+            "<b>Retrieval</b> filler filler filler...",
+        ),
+        (
+            "filler " * 10 + "retrieval",
+            "...filler filler filler <b>retrieval</b>",
+        ),
+    ],
+)
+def test_extract_snippet_highlights_match_at_text_edges(text, expected):
+    assert (
+        extract_snippet(text, "retrieval", window=30, highlight_tags=TAGS)
+        == expected
+    )
+
+
+def test_extract_snippet_picks_window_densest_in_terms():
+    text = (
+        "search "
+        + "filler " * 10
+        + "vector search over chunks "
+        + "filler " * 10
+    )
+    assert (
+        extract_snippet(text, "vector search", window=30, highlight_tags=TAGS)
+        == "...filler <b>vector</b> <b>search</b> over chun..."
+    )
+
+
+def test_extract_snippet_widens_window_over_cut_term():
+    text = "filler " * 10 + "search then retrieval " + "filler " * 10
+    # A 20 character window would end inside "retrieval"
+    assert (
+        extract_snippet(
+            text, "search retrieval", window=20, highlight_tags=TAGS
+        )
+        == "...ller <b>search</b> then <b>retrieval</b>..."
+    )